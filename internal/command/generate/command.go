@@ -16,11 +16,17 @@ func Command() *cobra.Command {
 
 	cmd.AddCommand(resourceCommand())
 	cmd.AddCommand(migrationCommand())
+	cmd.AddCommand(handlerCommand())
+	cmd.AddCommand(serviceCommand())
+	cmd.AddCommand(queryCommand())
+	cmd.AddCommand(jobCommand())
+	cmd.AddCommand(mailerCommand())
 	return cmd
 }
 
 func resourceCommand() *cobra.Command {
 	var quiet bool
+	var audited bool
 
 	cmd := &cobra.Command{
 		Use:   "resource <Name> <plural> [field:type ...]",
@@ -30,10 +36,18 @@ func resourceCommand() *cobra.Command {
 The first argument is the resource name (singular, e.g. "Post").
 The second argument is the plural table name (e.g. "posts").
 
-Fields are specified as name:type pairs.
+Fields are specified as name:type pairs. String and text fields may add the
+:searchable modifier to generate a full-text search query and a
+GET /<plural>/search?q= endpoint.
+
+With --audited the table gains created_by and updated_by columns, filled from
+the user set with service.WithActor, and a <plural>_history table recording
+before/after snapshots of every update and delete, served from
+GET /<plural>/:id/history.
 
 Example:
-  snowflake gen resource Post posts title:string body:text published:bool
+  snowflake gen resource Post posts title:string:searchable body:text:searchable published:bool
+  snowflake gen resource Invoice invoices number:string total:float --audited
 
 Valid field types: string, text, int, bigint, bool, float, timestamp`,
 		Args: cobra.MinimumNArgs(2),
@@ -49,6 +63,7 @@ Valid field types: string, text, int, bigint, bool, float, timestamp`,
 				RawFields:  args[2:],
 				ProjectDir: cwd,
 				Quiet:      quiet,
+				Audited:    audited,
 			}); err != nil {
 				log.Fatal(err)
 			}
@@ -56,6 +71,7 @@ Valid field types: string, text, int, bigint, bool, float, timestamp`,
 	}
 
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	cmd.Flags().BoolVar(&audited, "audited", false, "Add audit columns and a history table")
 	return cmd
 }
 
@@ -98,3 +114,212 @@ Valid field types: string, text, int, bigint, bool, float, timestamp`,
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
 	return cmd
 }
+
+func handlerCommand() *cobra.Command {
+	var (
+		quiet  bool
+		method string
+		path   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "handler <Name>",
+		Short: "Generate a standalone handler with route registration",
+		Long: `Generate a gin handler and a Register<Name>Routes function for a custom
+endpoint that is not backed by a full resource.
+
+Example:
+  snowflake gen handler Stats --method GET --path /stats`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := generate.RunHandler(generate.HandlerInput{
+				Name:       args[0],
+				Method:     method,
+				Path:       path,
+				ProjectDir: cwd,
+				Quiet:      quiet,
+			}); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	cmd.Flags().StringVar(&method, "method", "GET", "HTTP method of the route")
+	cmd.Flags().StringVar(&path, "path", "", "Route path (default /<name>)")
+	return cmd
+}
+
+func serviceCommand() *cobra.Command {
+	var quiet bool
+
+	cmd := &cobra.Command{
+		Use:   "service <Name> <plural>",
+		Short: "Generate a service wrapping existing repo queries",
+		Long: `Generate a service whose methods delegate to the repo methods sqlc generated
+for cmd/app/sql/queries/<plural>.sql. Run sqlc generate first.
+
+Example:
+  snowflake gen service Report posts`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := generate.RunService(generate.GenerateInput{
+				Name:       args[0],
+				Plural:     args[1],
+				ProjectDir: cwd,
+				Quiet:      quiet,
+			}); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	return cmd
+}
+
+func queryCommand() *cobra.Command {
+	var (
+		quiet   bool
+		command string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "query <plural> <QueryName> <sql>",
+		Short: "Append a named query to an existing queries file",
+		Long: `Append a named sqlc query to cmd/app/sql/queries/<plural>.sql and rerun
+sqlc generate.
+
+Example:
+  snowflake gen query posts ListPublishedPosts "SELECT * FROM posts WHERE published = true ORDER BY id DESC"
+  snowflake gen query posts PublishPost "UPDATE posts SET published = true WHERE id = \$1" --cmd exec`,
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := generate.RunQuery(generate.QueryInput{
+				Plural:     args[0],
+				Name:       args[1],
+				SQL:        args[2],
+				Command:    command,
+				ProjectDir: cwd,
+				Quiet:      quiet,
+			}); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	cmd.Flags().StringVar(&command, "cmd", "many", "sqlc query command: one, many, exec, execresult, execrows or execlastid")
+	return cmd
+}
+
+func jobCommand() *cobra.Command {
+	var (
+		quiet   bool
+		handler bool
+		cron    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "job <Name> [param:type ...]",
+		Short: "Generate a durable job and register it with the worker",
+		Long: `Generate a durable task in internal/jobs with typed params and result
+structs and step skeletons, and register it in jobs.Register.
+
+Params are specified as name:type pairs and become JSON fields of the
+<Name>JobParams struct. With --handler an enqueue handler is generated in
+cmd/app/handlers as well.
+
+With --cron the job is added to jobs.Schedules and spawned with zero params
+on that cron expression (UTC). Every app instance runs the scheduler; each
+tick is claimed in the database so it is enqueued only once.
+
+Requires a project generated with a job processor.
+
+Example:
+  snowflake gen job SendWelcome email:string user_id:bigint --handler
+  snowflake gen job PruneSessions --cron "*/5 * * * *"
+
+Valid param types: string, text, int, bigint, bool, float, timestamp`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := generate.RunJob(generate.JobInput{
+				Name:       args[0],
+				Params:     args[1:],
+				Handler:    handler,
+				Cron:       cron,
+				ProjectDir: cwd,
+				Quiet:      quiet,
+			}); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	cmd.Flags().BoolVar(&handler, "handler", false, "Also generate a handler that enqueues the job")
+	cmd.Flags().StringVar(&cron, "cron", "", "Cron expression to spawn the job on a schedule")
+	return cmd
+}
+
+func mailerCommand() *cobra.Command {
+	var quiet bool
+
+	cmd := &cobra.Command{
+		Use:   "mailer <Name> [field:type ...]",
+		Short: "Generate an HTML email rendered from a templ component",
+		Long: `Generate a templ email and its typed data struct in internal/smtp/emails,
+with a New<Name>Email constructor that renders it into an smtp.Email. The
+plain-text alternative is generated from the HTML.
+
+Fields are specified as name:type pairs and become fields of the
+<Name>EmailData struct.
+
+Requires a project generated with SMTP. Run templ generate afterwards if
+templ is not installed.
+
+Example:
+  snowflake gen mailer Welcome name:string trial_ends_at:timestamp
+
+Valid field types: string, text, int, bigint, bool, float, timestamp`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := generate.RunMailer(generate.MailerInput{
+				Name:       args[0],
+				Fields:     args[1:],
+				ProjectDir: cwd,
+				Quiet:      quiet,
+			}); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	return cmd
+}
@@ -20,9 +20,12 @@ func Command() *cobra.Command {
 		smtp                bool
 		storage             bool
 		templ               bool
+		tenancy             bool
+		devS3               bool
 		devDBDashboard      bool
 		devMailboxDashboard bool
 		devStorageDashboard bool
+		devJobsDashboard    bool
 	)
 
 	cmd := &cobra.Command{
@@ -62,9 +65,12 @@ func Command() *cobra.Command {
 				SMTP:                smtp,
 				Storage:             storage,
 				Templ:               templ,
+				Tenancy:             tenancy,
+				DevS3:               devS3,
 				DevDBDashboard:      devDBDashboard,
 				DevMailboxDashboard: devMailboxDashboard,
 				DevStorageDashboard: devStorageDashboard,
+				DevJobsDashboard:    devJobsDashboard,
 			})
 			if err != nil {
 				log.Fatal(err.Error())
@@ -78,13 +84,16 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&quiet, "quiet", false, "Disable project generation messages")
 	cmd.Flags().BoolVar(&git, "git", true, "Initialize git")
 	cmd.Flags().StringVar(&keyValueStore, "kvs", "none", fmt.Sprintf("Key-value store %v", initialize.AllKeyValueStores))
-	cmd.Flags().StringVar(&jobProcessor, "jobs", "none", fmt.Sprintf("Job processor, postgres only %v", initialize.AllJobProcessors))
+	cmd.Flags().StringVar(&jobProcessor, "jobs", "none", fmt.Sprintf("Job processor %v (absurd requires postgres, database requires sqlite3, mysql or mariadb, kvs requires --kvs)", initialize.AllJobProcessors))
 	cmd.Flags().BoolVar(&smtp, "smtp", false, "Add SMTP")
 	cmd.Flags().BoolVar(&storage, "storage", false, "Add Storage (S3)")
 	cmd.Flags().BoolVar(&templ, "templ", false, "Add HTML (templ)")
+	cmd.Flags().BoolVar(&devS3, "dev-s3", false, "Add a MinIO container to devenv.yaml and use it for S3 in development, requires --storage")
+	cmd.Flags().BoolVar(&tenancy, "tenancy", false, "Scope generated resources by tenant, requires a database")
 	cmd.Flags().BoolVar(&devDBDashboard, "dev-db-dashboard", false, "Add dev database dashboard")
 	cmd.Flags().BoolVar(&devMailboxDashboard, "dev-mailbox-dashboard", false, "Add dev mailbox dashboard")
 	cmd.Flags().BoolVar(&devStorageDashboard, "dev-storage-dashboard", false, "Add dev storage dashboard")
+	cmd.Flags().BoolVar(&devJobsDashboard, "dev-jobs-dashboard", false, "Add dev jobs dashboard, requires --jobs")
 
 	return cmd
 }
@@ -44,6 +44,23 @@ func Command() *cobra.Command {
 					Value(&selectedFeatures),
 			)
 
+			tenancyGroup := huh.NewGroup(
+				huh.NewConfirm().
+					Title("Scope generated resources by tenant?").
+					Value(&cfg.Tenancy),
+			).WithHideFunc(func() bool {
+				// Tenancy adds a tenant_id column to generated tables.
+				return database == initialize.DatabaseNone
+			})
+
+			devS3Group := huh.NewGroup(
+				huh.NewConfirm().
+					Title("Run S3 locally with MinIO in devenv.yaml?").
+					Value(&cfg.DevS3),
+			).WithHideFunc(func() bool {
+				return !contains(selectedFeatures, "Storage")
+			})
+
 			databaseGroup := huh.NewGroup(
 				huh.NewSelect[initialize.Database]().
 					Title("Select database").
@@ -71,14 +88,27 @@ func Command() *cobra.Command {
 			jobProcessorGroup := huh.NewGroup(
 				huh.NewSelect[initialize.JobProcessor]().
 					Title("Select job processor").
-					Options(
-						huh.NewOption("None", initialize.JobProcessorNone),
-						huh.NewOption("Absurd", initialize.JobProcessorAbsurd),
-					).
+					OptionsFunc(func() []huh.Option[initialize.JobProcessor] {
+						opts := []huh.Option[initialize.JobProcessor]{
+							huh.NewOption("None", initialize.JobProcessorNone),
+						}
+						// Absurd is Postgres-only; the other engines use the
+						// database processor.
+						switch database {
+						case initialize.DatabaseNone:
+						case initialize.DatabasePostgres:
+							opts = append(opts, huh.NewOption("Absurd", initialize.JobProcessorAbsurd))
+						default:
+							opts = append(opts, huh.NewOption("Database", initialize.JobProcessorDatabase))
+						}
+						if keyValueStore != initialize.KeyValueStoreNone {
+							opts = append(opts, huh.NewOption("Key-value store", initialize.JobProcessorKeyValueStore))
+						}
+						return opts
+					}, []any{&database, &keyValueStore}).
 					Value(&jobProcessor),
 			).WithHideFunc(func() bool {
-				// Job processing is currently only supported on Postgres.
-				return database != initialize.DatabasePostgres
+				return database == initialize.DatabaseNone && keyValueStore == initialize.KeyValueStoreNone
 			})
 
 			dashboardsGroup := huh.NewGroup(
@@ -95,14 +125,18 @@ func Command() *cobra.Command {
 						if contains(selectedFeatures, "Storage") {
 							opts = append(opts, huh.NewOption("Storage", "DevStorageDashboard"))
 						}
+						if jobProcessor != initialize.JobProcessorNone {
+							opts = append(opts, huh.NewOption("Jobs", "DevJobsDashboard"))
+						}
 						return opts
-					}, []any{&selectedFeatures, &database}).
+					}, []any{&selectedFeatures, &database, &jobProcessor}).
 					Value(&selectedDashboards),
 			).WithHideFunc(func() bool {
 				hasDB := database != initialize.DatabaseNone
 				hasSMTP := contains(selectedFeatures, "SMTP")
 				hasStorage := contains(selectedFeatures, "Storage")
-				return !hasDB && !hasSMTP && !hasStorage
+				hasJobs := jobProcessor != initialize.JobProcessorNone
+				return !hasDB && !hasSMTP && !hasStorage && !hasJobs
 			})
 
 			containerRuntimeGroup := huh.NewGroup(
@@ -120,7 +154,9 @@ func Command() *cobra.Command {
 				databaseGroup,
 				keyValueStoreGroup,
 				jobProcessorGroup,
+				tenancyGroup,
 				featuresGroup,
+				devS3Group,
 				dashboardsGroup,
 				containerRuntimeGroup,
 			)
@@ -151,6 +187,7 @@ func Command() *cobra.Command {
 			cfg.DevDBDashboard = contains(selectedDashboards, "DevDBDashboard")
 			cfg.DevMailboxDashboard = contains(selectedDashboards, "DevMailboxDashboard")
 			cfg.DevStorageDashboard = contains(selectedDashboards, "DevStorageDashboard")
+			cfg.DevJobsDashboard = contains(selectedDashboards, "DevJobsDashboard")
 
 			if err := initialize.Run(cfg); err != nil {
 				fmt.Printf("error creating project: %v\n", err)
@@ -13,6 +13,9 @@ import (
 type ProjectConfig struct {
 	Module   string
 	Database string
+	// Tenancy is set for projects generated with the tenant package; their
+	// resources are scoped by a tenant_id column.
+	Tenancy bool
 }
 
 func LoadConfig(dir string) (*ProjectConfig, error) {
@@ -29,9 +32,15 @@ func LoadConfig(dir string) (*ProjectConfig, error) {
 	return &ProjectConfig{
 		Module:   module,
 		Database: database,
+		Tenancy:  hasTenantPackage(dir),
 	}, nil
 }
 
+func hasTenantPackage(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "internal", "tenant", "tenant.go"))
+	return err == nil
+}
+
 func readModule(dir string) (string, error) {
 	f, err := os.Open(filepath.Join(dir, "go.mod"))
 	if err != nil {
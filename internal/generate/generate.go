@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -55,6 +56,31 @@ var funcMap = template.FuncMap{
 	"hasParamsStruct": func(fields []Field) bool {
 		return len(fields) > 1
 	},
+	// postgresSearchDocument concatenates the searchable fields into the text
+	// that is indexed and queried as a tsvector.
+	"postgresSearchDocument": func(fields []Field) string {
+		parts := make([]string, len(fields))
+		for i, f := range fields {
+			parts[i] = fmt.Sprintf("coalesce(%s, '')", f.Name)
+		}
+		return strings.Join(parts, " || ' ' || ")
+	},
+	// jsonPairs lists "'column', PREFIX.column" pairs for building a JSON
+	// snapshot of a row inside a trigger.
+	"jsonPairs": func(prefix string, columns []string) string {
+		pairs := make([]string, len(columns))
+		for i, column := range columns {
+			pairs[i] = fmt.Sprintf("'%s', %s.%s", column, prefix, column)
+		}
+		return strings.Join(pairs, ", ")
+	},
+	"prefixedFieldNames": func(prefix string, fields []Field) string {
+		names := make([]string, len(fields))
+		for i, f := range fields {
+			names[i] = prefix + "." + f.Name
+		}
+		return strings.Join(names, ", ")
+	},
 }
 
 type GenerateInput struct {
@@ -63,11 +89,15 @@ type GenerateInput struct {
 	RawFields  []string
 	ProjectDir string
 	Quiet      bool
+	Audited    bool
 }
 
 type generatedTarget struct {
 	templateName string
 	outputPath   string
+	// keepExisting leaves an existing file untouched, for support files shared
+	// by several resources.
+	keepExisting bool
 }
 
 type generationContext struct {
@@ -119,6 +149,20 @@ func Run(input GenerateInput) error {
 			outputPath:   filepath.Join(input.ProjectDir, "cmd", "app", "handlers", ctx.resource.Name+"_handler.go"),
 		},
 	}
+	if ctx.resource.HasSearch() && ctx.config.Database == "sqlite3" {
+		files = append(files, generatedTarget{
+			templateName: "fts.go.tmpl",
+			outputPath:   filepath.Join(input.ProjectDir, "cmd", "app", "handlers", "fts.go"),
+			keepExisting: true,
+		})
+	}
+	if ctx.resource.Audited {
+		files = append(files, generatedTarget{
+			templateName: "actor.go.tmpl",
+			outputPath:   filepath.Join(input.ProjectDir, "cmd", "app", "service", "actor.go"),
+			keepExisting: true,
+		})
+	}
 
 	goFiles, err := renderTargets(ctx.templates, ctx.resource, files, input.ProjectDir, input.Quiet)
 	if err != nil {
@@ -159,9 +203,12 @@ func prepareGeneration(input GenerateInput) (*generationContext, error) {
 		return nil, err
 	}
 
+	resource := NewResource(input.Name, input.Plural, fields, cfg)
+	resource.Audited = input.Audited
+
 	return &generationContext{
 		config:    cfg,
-		resource:  NewResource(input.Name, input.Plural, fields, cfg),
+		resource:  resource,
 		templates: templates,
 	}, nil
 }
@@ -193,6 +240,12 @@ func renderTargets(tmpl *template.Template, data any, targets []generatedTarget,
 }
 
 func renderTarget(tmpl *template.Template, data any, target generatedTarget, projectDir string, quiet bool, buf *bytes.Buffer) error {
+	if target.keepExisting {
+		if _, err := os.Stat(target.outputPath); err == nil {
+			return nil
+		}
+	}
+
 	buf.Reset()
 	if err := tmpl.ExecuteTemplate(buf, target.templateName, data); err != nil {
 		return fmt.Errorf("failed to execute template %s: %w", target.templateName, err)
@@ -267,61 +320,94 @@ func printSuccess(projectDir string, cfg *ProjectConfig, r *Resource) {
 	if len(r.Fields) > 0 {
 		fmt.Println()
 		for _, f := range r.Fields {
+			if f.Searchable {
+				fmt.Printf("    %s:%s:%s\n", f.Name, f.Type, fieldModifierSearchable)
+				continue
+			}
 			fmt.Printf("    %s:%s\n", f.Name, f.Type)
 		}
 	}
 	fmt.Printf("\n%s\n", routeInstructions(projectDir, cfg, r))
+	if r.Audited {
+		fmt.Println()
+		fmt.Println("Audited writes record the user set with service.WithActor on the request context.")
+		fmt.Println("Call it from your authentication middleware so created_by, updated_by and history rows are populated.")
+	}
 }
 
 func routeInstructions(projectDir string, cfg *ProjectConfig, resource *Resource) string {
+	return buildRouteInstructions(readRouter(projectDir), cfg, resource)
+}
+
+// readRouter returns the source of cmd/app/router.go, or "" when it is missing.
+func readRouter(projectDir string) string {
 	content, err := os.ReadFile(filepath.Join(projectDir, "cmd", "app", "router.go"))
 	if err != nil {
-		content = nil
+		return ""
 	}
-	return buildRouteInstructions(string(content), cfg, resource)
+	return string(content)
 }
 
 func buildRouteInstructions(content string, cfg *ProjectConfig, resource *Resource) string {
-	queriesLine := "queries := repo.New(db)"
-	serviceLine := fmt.Sprintf("%sService := service.New%sService(queries)", resource.Name, resource.NameTitle)
-	registerLine := fmt.Sprintf("handlers.Register%sRoutes(api, %sService)", resource.NameTitle, resource.Name)
-
-	needsQueries := !strings.Contains(content, queriesLine)
-	needsService := !strings.Contains(content, serviceLine)
-	needsRegister := !strings.Contains(content, registerLine)
-
-	var imports []string
-	if needsRegister && !hasImport(content, cfg.Module+"/cmd/app/handlers") {
-		imports = append(imports, fmt.Sprintf("%q", cfg.Module+"/cmd/app/handlers"))
+	lines := []routerLine{
+		{
+			code:       fmt.Sprintf("%sService := service.New%sService(s.db)", resource.Name, resource.NameTitle),
+			importPath: cfg.Module + "/cmd/app/service",
+		},
 	}
-	if needsQueries && !hasImport(content, cfg.Module+"/cmd/app/repo") {
-		imports = append(imports, fmt.Sprintf("%q", cfg.Module+"/cmd/app/repo"))
+
+	// Tenant-scoped routes get their own group, so the tenant is resolved
+	// before any of their handlers run.
+	if resource.Tenanted {
+		lines = append(lines, routerLine{
+			code:       fmt.Sprintf("%sRoutes := api.Group(\"\", tenant.Middleware(s.tenant))", resource.Name),
+			importPath: cfg.Module + "/internal/tenant",
+		}, routerLine{
+			code:       fmt.Sprintf("handlers.Register%sRoutes(%sRoutes, %sService)", resource.NameTitle, resource.Name, resource.Name),
+			importPath: cfg.Module + "/cmd/app/handlers",
+		})
+	} else {
+		lines = append(lines, routerLine{
+			code:       fmt.Sprintf("handlers.Register%sRoutes(api, %sService)", resource.NameTitle, resource.Name),
+			importPath: cfg.Module + "/cmd/app/handlers",
+		})
 	}
-	if needsService && !hasImport(content, cfg.Module+"/cmd/app/service") {
-		imports = append(imports, fmt.Sprintf("%q", cfg.Module+"/cmd/app/service"))
+
+	return buildRouterInstructions(content, lines, "this resource")
+}
+
+// routerLine is a statement newRouter needs, along with the package it uses.
+type routerLine struct {
+	code       string
+	importPath string
+}
+
+// buildRouterInstructions lists the imports and statements from lines that are
+// not yet present in the router source.
+func buildRouterInstructions(content string, lines []routerLine, subject string) string {
+	var imports, missing []string
+	for _, line := range lines {
+		if strings.Contains(content, line.code) {
+			continue
+		}
+		missing = append(missing, line.code)
+
+		quoted := fmt.Sprintf("%q", line.importPath)
+		if !hasImport(content, line.importPath) && !slices.Contains(imports, quoted) {
+			imports = append(imports, quoted)
+		}
 	}
 
 	var sections []string
 	if len(imports) > 0 {
 		sections = append(sections, "Add these imports to cmd/app/router.go:\n"+indentLines(imports))
 	}
-
-	var lines []string
-	if needsQueries {
-		lines = append(lines, queriesLine)
-	}
-	if needsService {
-		lines = append(lines, serviceLine)
-	}
-	if needsRegister {
-		lines = append(lines, registerLine)
-	}
-	if len(lines) > 0 {
-		sections = append(sections, "Add this inside newRouter in cmd/app/router.go:\n"+indentLines(lines))
+	if len(missing) > 0 {
+		sections = append(sections, "Add this inside newRouter in cmd/app/router.go:\n"+indentLines(missing))
 	}
 
 	if len(sections) == 0 {
-		return "Routes for this resource already appear to be declared in cmd/app/router.go."
+		return fmt.Sprintf("Routes for %s already appear to be declared in cmd/app/router.go.", subject)
 	}
 
 	return strings.Join(sections, "\n\n")
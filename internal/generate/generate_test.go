@@ -120,6 +120,186 @@ func TestGenerateMigration(t *testing.T) {
 	}
 }
 
+func TestGenerateResourceSearch(t *testing.T) {
+	tests := []struct {
+		database      string
+		wantMigration string
+		wantQuery     string
+	}{
+		{
+			database:      "postgres",
+			wantMigration: "USING GIN (to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, '')))",
+			wantQuery:     "websearch_to_tsquery('english', sqlc.arg(query))",
+		},
+		{
+			database:      "mysql",
+			wantMigration: "FULLTEXT INDEX articles_search_idx (title, body)",
+			wantQuery:     "MATCH(title, body) AGAINST (sqlc.arg(query) IN NATURAL LANGUAGE MODE)",
+		},
+		{
+			database:      "sqlite3",
+			wantMigration: "CREATE VIRTUAL TABLE articles_fts USING fts5(",
+			wantQuery:     "WHERE articles_fts MATCH sqlc.arg(query)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.database, func(t *testing.T) {
+			projectDir := t.TempDir()
+			setupProjectDir(t, projectDir, tt.database)
+
+			err := Run(GenerateInput{
+				Name:       "article",
+				Plural:     "articles",
+				RawFields:  []string{"title:string:searchable", "body:text:searchable", "views:int"},
+				ProjectDir: projectDir,
+				Quiet:      true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			migration := readMigration(t, projectDir)
+			if !strings.Contains(migration, tt.wantMigration) {
+				t.Errorf("expected migration to contain %q, got:\n%s", tt.wantMigration, migration)
+			}
+
+			queries := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "sql", "queries", "articles.sql"))
+			if !strings.Contains(queries, "-- name: SearchArticle :many") || !strings.Contains(queries, tt.wantQuery) {
+				t.Errorf("expected search query containing %q, got:\n%s", tt.wantQuery, queries)
+			}
+
+			handler := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "handlers", "article_handler.go"))
+			if !strings.Contains(handler, `api.GET("/articles/search", HandleSearchArticle(articleService))`) {
+				t.Errorf("expected search route to be registered, got:\n%s", handler)
+			}
+
+			// Only FTS5 parses the query as syntax, so only SQLite escapes it.
+			ftsPath := filepath.Join(projectDir, "cmd", "app", "handlers", "fts.go")
+			if tt.database == "sqlite3" {
+				fts := mustReadFile(t, ftsPath)
+				if !strings.Contains(fts, "func ftsQuery(input string) string {") || !strings.Contains(fts, "strings.ReplaceAll(term, `\"`, `\"\"`)") {
+					t.Errorf("expected the FTS5 escaping helper, got:\n%s", fts)
+				}
+				if !strings.Contains(handler, "Query:    ftsQuery(query),") {
+					t.Errorf("expected the search query to be escaped, got:\n%s", handler)
+				}
+			} else if _, err := os.Stat(ftsPath); !os.IsNotExist(err) {
+				t.Errorf("expected no FTS5 helper for %s", tt.database)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceWithoutSearch(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := Run(GenerateInput{
+		Name:       "post",
+		Plural:     "posts",
+		RawFields:  []string{"title:string"},
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "sql", "queries", "posts.sql"))
+	if strings.Contains(queries, "SearchPost") {
+		t.Errorf("expected no search query without searchable fields, got:\n%s", queries)
+	}
+}
+
+func TestGenerateResourceAudited(t *testing.T) {
+	tests := []struct {
+		database    string
+		wantTrigger string
+	}{
+		{database: "postgres", wantTrigger: "AFTER UPDATE OR DELETE ON invoices"},
+		{database: "mysql", wantTrigger: "JSON_OBJECT('id', OLD.id, 'number', OLD.number, 'created_by', OLD.created_by"},
+		{database: "sqlite3", wantTrigger: "AFTER UPDATE OF number, updated_by ON invoices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.database, func(t *testing.T) {
+			projectDir := t.TempDir()
+			setupProjectDir(t, projectDir, tt.database)
+
+			err := Run(GenerateInput{
+				Name:       "invoice",
+				Plural:     "invoices",
+				RawFields:  []string{"number:string"},
+				ProjectDir: projectDir,
+				Quiet:      true,
+				Audited:    true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			migration := readMigration(t, projectDir)
+			for _, want := range []string{"created_by", "updated_by", "CREATE TABLE invoices_history", tt.wantTrigger} {
+				if !strings.Contains(migration, want) {
+					t.Errorf("expected migration to contain %q, got:\n%s", want, migration)
+				}
+			}
+
+			queries := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "sql", "queries", "invoices.sql"))
+			for _, want := range []string{"number, created_by, updated_by", "-- name: ListInvoiceHistory :many", "-- name: SetInvoiceDeletedBy :exec"} {
+				if !strings.Contains(queries, want) {
+					t.Errorf("expected queries to contain %q, got:\n%s", want, queries)
+				}
+			}
+
+			svc := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "service", "invoice_service.go"))
+			if !strings.Contains(svc, "arg.CreatedBy = ActorFromContext(ctx)") || !strings.Contains(svc, "q.SetInvoiceDeletedBy(ctx") {
+				t.Errorf("expected service to stamp the actor, got:\n%s", svc)
+			}
+
+			handler := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "handlers", "invoice_handler.go"))
+			if !strings.Contains(handler, `api.GET("/invoices/:id/history", HandleListInvoiceHistory(invoiceService))`) {
+				t.Errorf("expected history route to be registered, got:\n%s", handler)
+			}
+
+			if _, err := os.Stat(filepath.Join(projectDir, "cmd", "app", "service", "actor.go")); err != nil {
+				t.Errorf("expected actor.go to be generated: %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateResourceAuditedKeepsActor(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	actorPath := filepath.Join(projectDir, "cmd", "app", "service", "actor.go")
+	if err := os.MkdirAll(filepath.Dir(actorPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	custom := "package service\n\n// customised\n"
+	if err := os.WriteFile(actorPath, []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Run(GenerateInput{
+		Name:       "invoice",
+		Plural:     "invoices",
+		RawFields:  []string{"number:string"},
+		ProjectDir: projectDir,
+		Quiet:      true,
+		Audited:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := mustReadFile(t, actorPath); got != custom {
+		t.Errorf("expected existing actor.go to be kept, got:\n%s", got)
+	}
+}
+
 func TestGenerateResourceNoDB(t *testing.T) {
 	projectDir := t.TempDir()
 
@@ -192,10 +372,8 @@ func TestRouteInstructions(t *testing.T) {
 	for _, want := range []string{
 		`Add these imports to cmd/app/router.go:`,
 		`"acme/cmd/app/handlers"`,
-		`"acme/cmd/app/repo"`,
 		`"acme/cmd/app/service"`,
-		`queries := repo.New(db)`,
-		`postService := service.NewPostService(queries)`,
+		`postService := service.NewPostService(s.db)`,
 		`handlers.RegisterPostRoutes(api, postService)`,
 	} {
 		if !strings.Contains(instructions, want) {
@@ -204,6 +382,169 @@ func TestRouteInstructions(t *testing.T) {
 	}
 }
 
+func TestGenerateResourceTenanted(t *testing.T) {
+	tests := []struct {
+		database  string
+		wantGet   string
+		wantIndex string
+	}{
+		{database: "postgres", wantGet: "WHERE tenant_id = $1 AND id = $2", wantIndex: "CREATE INDEX posts_tenant_id_idx ON posts (tenant_id, id);"},
+		{database: "mysql", wantGet: "WHERE tenant_id = ? AND id = ?", wantIndex: "INDEX posts_tenant_id_idx (tenant_id, id)"},
+		{database: "sqlite3", wantGet: "WHERE tenant_id = ? AND id = ?", wantIndex: "CREATE INDEX posts_tenant_id_idx ON posts (tenant_id, id);"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.database, func(t *testing.T) {
+			projectDir := t.TempDir()
+			setupProjectDir(t, projectDir, tt.database)
+			setupTenantPackage(t, projectDir)
+
+			err := Run(GenerateInput{
+				Name:       "post",
+				Plural:     "posts",
+				RawFields:  []string{"title:string"},
+				ProjectDir: projectDir,
+				Quiet:      true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			migration := readMigration(t, projectDir)
+			for _, want := range []string{"tenant_id", tt.wantIndex} {
+				if !strings.Contains(migration, want) {
+					t.Errorf("expected migration to contain %q, got:\n%s", want, migration)
+				}
+			}
+
+			queries := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "sql", "queries", "posts.sql"))
+			for _, want := range []string{tt.wantGet, "tenant_id, title"} {
+				if !strings.Contains(queries, want) {
+					t.Errorf("expected queries to contain %q, got:\n%s", want, queries)
+				}
+			}
+			// Every statement except the insert must filter by tenant.
+			for _, stmt := range strings.Split(queries, "-- name: ")[1:] {
+				if strings.HasPrefix(stmt, "CreatePost") {
+					continue
+				}
+				if !strings.Contains(stmt, "WHERE tenant_id = ") {
+					t.Errorf("expected query to filter by tenant, got:\n%s", stmt)
+				}
+			}
+
+			svc := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "service", "post_service.go"))
+			if !strings.Contains(svc, `"acme/internal/tenant"`) || !strings.Contains(svc, "tenant.FromContext(ctx)") {
+				t.Errorf("expected service to read the tenant from context, got:\n%s", svc)
+			}
+		})
+	}
+}
+
+func TestRouteInstructionsTenanted(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupTenantPackage(t, projectDir)
+
+	cfg, err := LoadConfig(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Tenancy {
+		t.Fatal("expected tenancy to be detected from internal/tenant")
+	}
+
+	instructions := routeInstructions(projectDir, cfg, NewResource("post", "posts", nil, cfg))
+	for _, want := range []string{
+		`postRoutes := api.Group("", tenant.Middleware(s.tenant))`,
+		"handlers.RegisterPostRoutes(postRoutes, postService)",
+		`"acme/internal/tenant"`,
+	} {
+		if !strings.Contains(instructions, want) {
+			t.Errorf("expected instructions to contain %q, got:\n%s", want, instructions)
+		}
+	}
+}
+
+func TestGenerateServiceUsesTransactions(t *testing.T) {
+	tests := []struct {
+		database string
+		wantTx   bool
+	}{
+		{database: "postgres", wantTx: false},
+		{database: "sqlite3", wantTx: false},
+		{database: "mysql", wantTx: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.database, func(t *testing.T) {
+			projectDir := t.TempDir()
+			setupProjectDir(t, projectDir, tt.database)
+
+			err := Run(GenerateInput{
+				Name:       "post",
+				Plural:     "posts",
+				RawFields:  []string{"title:string", "body:text"},
+				ProjectDir: projectDir,
+				Quiet:      true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "service", "post_service.go"))
+
+			if !strings.Contains(service, "func NewPostService(database *sql.DB) *PostService") {
+				t.Errorf("expected service constructor to accept *sql.DB, got:\n%s", service)
+			}
+			if got := strings.Contains(service, "db.WithTx("); got != tt.wantTx {
+				t.Errorf("expected db.WithTx usage to be %v, got:\n%s", tt.wantTx, service)
+			}
+		})
+	}
+}
+
+func readMigration(t *testing.T, projectDir string) string {
+	t.Helper()
+
+	migrationsDir := filepath.Join(projectDir, "cmd", "app", "sql", "migrations")
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".sql" {
+			return mustReadFile(t, filepath.Join(migrationsDir, e.Name()))
+		}
+	}
+
+	t.Fatal("no migration file generated")
+	return ""
+}
+
+func mustReadFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	return string(content)
+}
+
+func setupTenantPackage(t *testing.T, projectDir string) {
+	t.Helper()
+
+	dir := filepath.Join(projectDir, "internal", "tenant")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tenant.go"), []byte("package tenant\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func setupProjectDir(t *testing.T, projectDir string, database string) {
 	t.Helper()
 
@@ -0,0 +1,105 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var validHandlerMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+type HandlerInput struct {
+	Name       string
+	Method     string
+	Path       string
+	ProjectDir string
+	Quiet      bool
+}
+
+// handlerData is the template data for a standalone handler.
+type handlerData struct {
+	Name      string
+	NameTitle string
+	Method    string
+	Path      string
+}
+
+// RunHandler generates a single gin handler with a route registration
+// function, for endpoints that are not backed by a full resource.
+func RunHandler(input HandlerInput) error {
+	// Handlers do not need a database, so only the module path is read.
+	module, err := readModule(input.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	data, err := newHandlerData(input)
+	if err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(input.ProjectDir, "cmd", "app", "handlers", data.Name+"_handler.go")
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists", filepath.Join("cmd", "app", "handlers", data.Name+"_handler.go"))
+	}
+
+	templates, err := parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	goFiles, err := renderTargets(templates, data, []generatedTarget{
+		{templateName: "custom_handler.go.tmpl", outputPath: outputPath},
+	}, input.ProjectDir, input.Quiet)
+	if err != nil {
+		return err
+	}
+	_ = runGenCommand("gofmt", append([]string{"-w", "-s"}, goFiles...), input.ProjectDir, true)
+
+	if !input.Quiet {
+		fmt.Printf("\n%s\n", handlerRouteInstructions(readRouter(input.ProjectDir), module, data))
+	}
+
+	return nil
+}
+
+func newHandlerData(input HandlerInput) (*handlerData, error) {
+	name := strings.TrimSpace(input.Name)
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid handler name %q, expected a name like stats", input.Name)
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(input.Method))
+	if method == "" {
+		method = "GET"
+	}
+	if !slices.Contains(validHandlerMethods, method) {
+		return nil, fmt.Errorf("invalid method %q. Must be one of: %s", input.Method, strings.Join(validHandlerMethods, ", "))
+	}
+
+	path := strings.TrimSpace(input.Path)
+	if path == "" {
+		path = "/" + strings.ToLower(name)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return &handlerData{
+		Name:      strings.ToLower(name),
+		NameTitle: toTitle(name),
+		Method:    method,
+		Path:      path,
+	}, nil
+}
+
+func handlerRouteInstructions(content string, module string, data *handlerData) string {
+	return buildRouterInstructions(content, []routerLine{
+		{
+			code:       fmt.Sprintf("handlers.Register%sRoutes(api)", data.NameTitle),
+			importPath: module + "/cmd/app/handlers",
+		},
+	}, "this handler")
+}
@@ -0,0 +1,121 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunHandler(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := RunHandler(HandlerInput{
+		Name:       "stats",
+		Method:     "post",
+		Path:       "stats/refresh",
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(projectDir, "cmd", "app", "handlers", "stats_handler.go")
+	handler := mustReadFile(t, path)
+	if _, err := parser.ParseFile(token.NewFileSet(), path, handler, 0); err != nil {
+		t.Fatalf("generated handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		"func HandleStats() gin.HandlerFunc",
+		`api.POST("/stats/refresh", HandleStats())`,
+	} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("expected handler to contain %q, got:\n%s", want, handler)
+		}
+	}
+
+	// Only the handler is generated.
+	for _, dir := range []string{"service", filepath.Join("sql", "queries")} {
+		entries, err := os.ReadDir(filepath.Join(projectDir, "cmd", "app", dir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("expected cmd/app/%s to be untouched, found %d file(s)", dir, len(entries))
+		}
+	}
+}
+
+func TestRunHandlerExisting(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	input := HandlerInput{Name: "stats", ProjectDir: projectDir, Quiet: true}
+	if err := RunHandler(input); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunHandler(input); err == nil {
+		t.Fatal("expected an error when the handler file already exists")
+	}
+}
+
+func TestRunHandlerInvalidMethod(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := RunHandler(HandlerInput{Name: "stats", Method: "TRACE", ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "invalid method") {
+		t.Fatalf("expected invalid method error, got %v", err)
+	}
+}
+
+func TestRunHandlerInvalidName(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	for _, name := range []string{"", "../../x", "my stats", "1stats"} {
+		err := RunHandler(HandlerInput{Name: name, ProjectDir: projectDir, Quiet: true})
+		if err == nil || !strings.Contains(err.Error(), "invalid handler name") {
+			t.Errorf("expected invalid handler name error for %q, got %v", name, err)
+		}
+	}
+}
+
+func TestRunHandlerQuotesPath(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := RunHandler(HandlerInput{Name: "stats", Path: `stats/"quoted"`, ProjectDir: projectDir, Quiet: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(projectDir, "cmd", "app", "handlers", "stats_handler.go")
+	handler := mustReadFile(t, path)
+	if _, err := parser.ParseFile(token.NewFileSet(), path, handler, 0); err != nil {
+		t.Fatalf("generated handler does not parse: %v", err)
+	}
+	if want := `api.GET("/stats/\"quoted\"", HandleStats())`; !strings.Contains(handler, want) {
+		t.Errorf("expected handler to contain %q, got:\n%s", want, handler)
+	}
+}
+
+func TestHandlerRouteInstructions(t *testing.T) {
+	data := &handlerData{NameTitle: "Stats"}
+
+	instructions := handlerRouteInstructions("", "acme", data)
+	for _, want := range []string{`"acme/cmd/app/handlers"`, "handlers.RegisterStatsRoutes(api)"} {
+		if !strings.Contains(instructions, want) {
+			t.Errorf("expected instructions to contain %q, got:\n%s", want, instructions)
+		}
+	}
+
+	router := "import \"acme/cmd/app/handlers\"\n\thandlers.RegisterStatsRoutes(api)\n"
+	if got := handlerRouteInstructions(router, "acme", data); !strings.Contains(got, "already appear") {
+		t.Errorf("expected no instructions for a registered handler, got:\n%s", got)
+	}
+}
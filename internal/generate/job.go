@@ -0,0 +1,455 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// namePattern matches the names of generated types and files: it must start
+// with a letter so it becomes a valid Go identifier once title-cased, and it
+// cannot contain path separators.
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+type JobInput struct {
+	Name    string
+	Params  []string
+	Handler bool
+	// Cron, when set, adds the job to jobs.Schedules with this expression.
+	Cron       string
+	ProjectDir string
+	Quiet      bool
+}
+
+// jobData is the template data for a durable task.
+type jobData struct {
+	Module string
+	// Type is the exported task variable, e.g. SendWelcomeJob.
+	Type string
+	// TaskName is the name the task is registered under, e.g. send-welcome-job.
+	TaskName string
+	// Slug names the task file and the enqueue route, e.g. send_welcome.
+	Slug string
+	// TaskPackage provides Task, Step and MustTaskContext; TaskImport is its
+	// import path.
+	TaskPackage string
+	TaskImport  string
+	Params      []Field
+	HasTime     bool
+	Cron        string
+}
+
+// RunJob generates a durable task in internal/jobs, registers it in
+// jobs.Register and optionally emits a handler that enqueues it.
+func RunJob(input JobInput) error {
+	module, err := readModule(input.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	jobsDir := filepath.Join(input.ProjectDir, "internal", "jobs")
+	if !hasJobProcessor(input.ProjectDir) {
+		return fmt.Errorf("internal/jobs/jobs.go not found - this project has no job processor")
+	}
+
+	data, err := newJobData(input)
+	if err != nil {
+		return err
+	}
+	data.Module = module
+	data.TaskPackage, data.TaskImport = jobsTaskPackage(input.ProjectDir, module)
+
+	outputPath := filepath.Join(jobsDir, data.Slug+"_job.go")
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists", filepath.Join("internal", "jobs", data.Slug+"_job.go"))
+	}
+
+	targets := []generatedTarget{
+		{templateName: "job.go.tmpl", outputPath: outputPath},
+	}
+	if input.Handler {
+		handlerPath := filepath.Join(input.ProjectDir, "cmd", "app", "handlers", data.Slug+"_job_handler.go")
+		if _, err := os.Stat(handlerPath); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join("cmd", "app", "handlers", data.Slug+"_job_handler.go"))
+		}
+		targets = append(targets, generatedTarget{templateName: "job_handler.go.tmpl", outputPath: handlerPath})
+	}
+
+	// Edit the jobs package before writing any file so a failure leaves the
+	// project untouched.
+	edits := []jobsEdit{{
+		name: "func Register",
+		edit: func(src []byte) ([]byte, bool, error) { return addRegisterCall(src, data.Type) },
+	}}
+	if data.Cron != "" {
+		edits = append(edits, jobsEdit{
+			name: "var Schedules",
+			edit: func(src []byte) ([]byte, bool, error) { return addSchedule(src, data) },
+		})
+	}
+	edited, err := editJobsPackage(jobsDir, edits)
+	if err != nil {
+		return err
+	}
+
+	templates, err := parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	goFiles, err := renderTargets(templates, data, targets, input.ProjectDir, input.Quiet)
+	if err != nil {
+		return err
+	}
+	for _, file := range edited {
+		if err := os.WriteFile(file.path, file.content, 0666); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.path, err)
+		}
+		if !input.Quiet {
+			rel, _ := filepath.Rel(input.ProjectDir, file.path)
+			fmt.Printf("  updated %s\n", rel)
+		}
+	}
+	_ = runGenCommand("gofmt", append([]string{"-w", "-s"}, goFiles...), input.ProjectDir, true)
+
+	if !input.Quiet && input.Handler {
+		fmt.Printf("\n%s\n", jobRouteInstructions(readRouter(input.ProjectDir), module, data))
+	}
+
+	return nil
+}
+
+func hasJobProcessor(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "internal", "jobs", "jobs.go"))
+	return err == nil
+}
+
+// jobsTaskPackage returns the package tasks are defined with: the queue
+// package of projects using the database processor, Absurd otherwise.
+func jobsTaskPackage(dir string, module string) (name string, importPath string) {
+	if info, err := os.Stat(filepath.Join(dir, "internal", "jobs", "queue")); err == nil && info.IsDir() {
+		return "queue", module + "/internal/jobs/queue"
+	}
+	return "absurd", "github.com/earendil-works/absurd/sdks/go/absurd"
+}
+
+func newJobData(input JobInput) (*jobData, error) {
+	name := strings.TrimSpace(input.Name)
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid job name %q, expected a name like SendWelcome", input.Name)
+	}
+
+	base := strings.TrimSuffix(toTitle(name), "Job")
+	if base == "" {
+		return nil, fmt.Errorf("invalid job name %q, expected a name like SendWelcome", input.Name)
+	}
+	slug := toSnake(base)
+
+	params, err := parseJobParams(input.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	expr := strings.Join(strings.Fields(input.Cron), " ")
+	if expr != "" && !validCronExpr(expr) {
+		return nil, fmt.Errorf("invalid cron expression %q, expected five fields (e.g. \"*/5 * * * *\") or a descriptor such as @hourly", input.Cron)
+	}
+
+	data := &jobData{
+		Type:     base + "Job",
+		TaskName: strings.ReplaceAll(slug, "_", "-") + "-job",
+		Slug:     slug,
+		Params:   params,
+		Cron:     expr,
+	}
+	for _, p := range params {
+		if p.GoType == "time.Time" {
+			data.HasTime = true
+		}
+	}
+
+	return data, nil
+}
+
+// parseJobParams parses name:type pairs into fields. Job parameters are
+// serialized as JSON, so only the Go type of each mapping is used.
+func parseJobParams(raw []string) ([]Field, error) {
+	params := make([]Field, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		name, typeName, ok := strings.Cut(r, ":")
+		if !ok || name == "" || typeName == "" {
+			return nil, fmt.Errorf("invalid param %q, expected name:type format (e.g. email:string)\n%s", r, validFieldTypesHelp)
+		}
+
+		mapping, ok := typeMapping[typeName]
+		if !ok {
+			return nil, fmt.Errorf("unknown param type %q in %q\n%s", typeName, r, validFieldTypesHelp)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate param %q", name)
+		}
+		seen[name] = true
+
+		params = append(params, Field{
+			Name:      name,
+			NameTitle: toTitle(name),
+			Type:      typeName,
+			GoType:    mapping.GoType,
+		})
+	}
+
+	return params, nil
+}
+
+// jobsEdit rewrites the file of the jobs package that declares name. edit
+// reports found=false for files that do not declare it.
+type jobsEdit struct {
+	name string
+	edit func(src []byte) (updated []byte, found bool, err error)
+}
+
+type editedFile struct {
+	path    string
+	content []byte
+}
+
+// editJobsPackage applies each edit to the first file in jobsDir it matches.
+// Nothing is written; the edited files are returned in order.
+func editJobsPackage(jobsDir string, edits []jobsEdit) ([]editedFile, error) {
+	entries, err := os.ReadDir(jobsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []editedFile
+	for _, e := range edits {
+		var found bool
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+				continue
+			}
+
+			path := filepath.Join(jobsDir, entry.Name())
+			idx := slices.IndexFunc(files, func(f editedFile) bool { return f.path == path })
+
+			var src []byte
+			if idx >= 0 {
+				src = files[idx].content
+			} else if src, err = os.ReadFile(path); err != nil {
+				return nil, err
+			}
+
+			updated, ok, err := e.edit(src)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", filepath.Join("internal", "jobs", entry.Name()), err)
+			}
+			if !ok {
+				continue
+			}
+
+			if idx >= 0 {
+				files[idx].content = updated
+			} else {
+				files = append(files, editedFile{path: path, content: updated})
+			}
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("%s not found in internal/jobs", e.name)
+		}
+	}
+
+	return files, nil
+}
+
+// addRegisterCall inserts "<client>.MustRegister(<jobType>)" at the end of
+// the Register function in src. found is false when src does not declare
+// Register.
+func addRegisterCall(src []byte, jobType string) (updated []byte, found bool, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var register *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "Register" {
+			register = fn
+			break
+		}
+	}
+	if register == nil || register.Body == nil {
+		return nil, false, nil
+	}
+
+	params := register.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 1 {
+		return nil, true, fmt.Errorf("expected Register to take a single client parameter")
+	}
+	client := params[0].Names[0].Name
+
+	var registered bool
+	ast.Inspect(register.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "MustRegister" {
+			return true
+		}
+		for _, arg := range call.Args {
+			if ident, ok := arg.(*ast.Ident); ok && ident.Name == jobType {
+				registered = true
+			}
+		}
+		return true
+	})
+	if registered {
+		return nil, true, fmt.Errorf("%s is already registered", jobType)
+	}
+
+	offset := fset.Position(register.Body.Rbrace).Offset
+	var buf bytes.Buffer
+	buf.Write(src[:offset])
+	fmt.Fprintf(&buf, "\t%s.MustRegister(%s)\n", client, jobType)
+	buf.Write(src[offset:])
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, true, err
+	}
+
+	return formatted, true, nil
+}
+
+// addSchedule appends an entry spawning data.Type with zero params to the
+// Schedules slice in src. found is false when src does not declare Schedules.
+func addSchedule(src []byte, data *jobData) (updated []byte, found bool, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var list *ast.CompositeLit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name != "Schedules" {
+					continue
+				}
+				if i >= len(vs.Values) {
+					return nil, true, fmt.Errorf("expected Schedules to be initialized with a slice literal")
+				}
+				lit, ok := vs.Values[i].(*ast.CompositeLit)
+				if !ok {
+					return nil, true, fmt.Errorf("expected Schedules to be initialized with a slice literal")
+				}
+				list = lit
+			}
+		}
+	}
+	if list == nil {
+		return nil, false, nil
+	}
+
+	for _, elt := range list.Elts {
+		lit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		for _, field := range lit.Elts {
+			kv, ok := field.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok || key.Name != "Name" {
+				continue
+			}
+			if value, ok := kv.Value.(*ast.BasicLit); ok && value.Value == strconv.Quote(data.TaskName) {
+				return nil, true, fmt.Errorf("schedule %q already exists", data.TaskName)
+			}
+		}
+	}
+
+	offset := fset.Position(list.Rbrace).Offset
+
+	var entry strings.Builder
+	if src[offset-1] != '\n' {
+		entry.WriteString("\n")
+	}
+	fmt.Fprintf(&entry, "{\nName: %q,\nCron: %q,\n", data.TaskName, data.Cron)
+	entry.WriteString("Spawn: func(ctx context.Context, client *Client) error {\n")
+	fmt.Fprintf(&entry, "_, err := %s.Spawn(ctx, client, %sParams{})\nreturn err\n},\n},\n", data.Type, data.Type)
+
+	var buf bytes.Buffer
+	buf.Write(src[:offset])
+	buf.WriteString(entry.String())
+	buf.Write(src[offset:])
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, true, err
+	}
+
+	return formatted, true, nil
+}
+
+// validCronExpr does a shallow check of a cron expression; the scheduler
+// parses it fully when the application starts.
+func validCronExpr(expr string) bool {
+	if strings.HasPrefix(expr, "@") {
+		return len(expr) > 1
+	}
+	return len(strings.Fields(expr)) == 5
+}
+
+func jobRouteInstructions(content string, module string, data *jobData) string {
+	return buildRouterInstructions(content, []routerLine{
+		{
+			code:       fmt.Sprintf(`api.POST("/jobs/%s", handlers.HandleEnqueue%s(s.jobs))`, strings.ReplaceAll(data.Slug, "_", "-"), data.Type),
+			importPath: module + "/cmd/app/handlers",
+		},
+	}, "this job")
+}
+
+// toSnake converts a CamelCase or kebab-case name to snake_case, keeping
+// acronyms together (HTTPPing becomes http_ping).
+func toSnake(s string) string {
+	runes := []rune(strings.ReplaceAll(s, "-", "_"))
+
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '_' {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	return builder.String()
+}
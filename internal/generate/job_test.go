@@ -0,0 +1,312 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTasksFile = `package jobs
+
+// Register registers all application tasks on the worker client.
+func Register(client *Client) {
+	client.MustRegister(ExampleJob)
+}
+`
+
+const testScheduleFile = `package jobs
+
+import (
+	"context"
+)
+
+type Schedule struct {
+	Name  string
+	Cron  string
+	Spawn func(ctx context.Context, client *Client) error
+}
+
+var Schedules = []Schedule{}
+`
+
+func setupJobsPackage(t *testing.T, projectDir string) {
+	t.Helper()
+
+	dir := filepath.Join(projectDir, "internal", "jobs")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "jobs.go"), []byte("package jobs\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tasks.go"), []byte(testTasksFile), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "schedule.go"), []byte(testScheduleFile), 0666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+
+	err := RunJob(JobInput{
+		Name:       "SendWelcome",
+		Params:     []string{"email:string", "user_id:bigint", "send_at:timestamp"},
+		Handler:    true,
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobPath := filepath.Join(projectDir, "internal", "jobs", "send_welcome_job.go")
+	job := mustReadFile(t, jobPath)
+	if _, err := parser.ParseFile(token.NewFileSet(), jobPath, job, 0); err != nil {
+		t.Fatalf("generated job does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"time"`,
+		"type SendWelcomeJobParams struct",
+		"Email  string    `json:\"email\"`",
+		"UserId int64     `json:\"user_id\"`",
+		"SendAt time.Time `json:\"send_at\"`",
+		"type SendWelcomeJobResult struct",
+		"var SendWelcomeJob = absurd.Task(",
+		`"send-welcome-job"`,
+		`absurd.Step(ctx, "prepare"`,
+		`absurd.Step(ctx, "process"`,
+	} {
+		if !strings.Contains(job, want) {
+			t.Errorf("expected job to contain %q, got:\n%s", want, job)
+		}
+	}
+
+	tasks := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "tasks.go"))
+	if !strings.Contains(tasks, "\tclient.MustRegister(ExampleJob)\n\tclient.MustRegister(SendWelcomeJob)\n}") {
+		t.Errorf("expected SendWelcomeJob to be registered, got:\n%s", tasks)
+	}
+
+	handlerPath := filepath.Join(projectDir, "cmd", "app", "handlers", "send_welcome_job_handler.go")
+	handler := mustReadFile(t, handlerPath)
+	if _, err := parser.ParseFile(token.NewFileSet(), handlerPath, handler, 0); err != nil {
+		t.Fatalf("generated handler does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"acme/internal/jobs"`,
+		"func HandleEnqueueSendWelcomeJob(client *jobs.Client) gin.HandlerFunc",
+		"var params jobs.SendWelcomeJobParams",
+		"jobs.SendWelcomeJob.Spawn(c.Request.Context(), client, params)",
+	} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("expected handler to contain %q, got:\n%s", want, handler)
+		}
+	}
+}
+
+func TestRunJobWithoutHandler(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+
+	if err := RunJob(JobInput{Name: "cleanup", ProjectDir: projectDir, Quiet: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "cleanup_job.go"))
+	if strings.Contains(job, `"time"`) {
+		t.Errorf("expected no time import without timestamp params, got:\n%s", job)
+	}
+
+	if _, err := os.Stat(filepath.Join(projectDir, "cmd", "app", "handlers", "cleanup_job_handler.go")); !os.IsNotExist(err) {
+		t.Errorf("expected no handler without --handler, got err=%v", err)
+	}
+}
+
+func TestRunJobExisting(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+
+	input := JobInput{Name: "Cleanup", ProjectDir: projectDir, Quiet: true}
+	if err := RunJob(input); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunJob(input); err == nil {
+		t.Fatal("expected an error when the job file already exists")
+	}
+
+	// A task registered by hand is not registered twice.
+	if err := os.Remove(filepath.Join(projectDir, "internal", "jobs", "cleanup_job.go")); err != nil {
+		t.Fatal(err)
+	}
+	err := RunJob(input)
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("expected already registered error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "jobs", "cleanup_job.go")); !os.IsNotExist(err) {
+		t.Errorf("expected no job file after a failed registration, got err=%v", err)
+	}
+}
+
+func TestRunJobDatabaseProcessor(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "sqlite3")
+	setupJobsPackage(t, projectDir)
+	if err := os.MkdirAll(filepath.Join(projectDir, "internal", "jobs", "queue"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RunJob(JobInput{Name: "SendWelcome", ProjectDir: projectDir, Quiet: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "send_welcome_job.go"))
+	for _, want := range []string{
+		`"acme/internal/jobs/queue"`,
+		"var SendWelcomeJob = queue.Task(",
+		"task := queue.MustTaskContext(ctx)",
+		`queue.Step(ctx, "process"`,
+	} {
+		if !strings.Contains(job, want) {
+			t.Errorf("expected job to contain %q, got:\n%s", want, job)
+		}
+	}
+	if strings.Contains(job, "absurd") {
+		t.Errorf("expected no absurd reference with the database processor, got:\n%s", job)
+	}
+}
+
+func TestRunJobCron(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+
+	for _, input := range []JobInput{
+		{Name: "PruneSessions", Cron: "*/5  * * * *"},
+		{Name: "SendDigest", Cron: "@daily"},
+	} {
+		input.ProjectDir = projectDir
+		input.Quiet = true
+		if err := RunJob(input); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schedulePath := filepath.Join(projectDir, "internal", "jobs", "schedule.go")
+	schedule := mustReadFile(t, schedulePath)
+	if _, err := parser.ParseFile(token.NewFileSet(), schedulePath, schedule, 0); err != nil {
+		t.Fatalf("schedule file does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`Name: "prune-sessions-job",`,
+		`Cron: "*/5 * * * *",`,
+		"_, err := PruneSessionsJob.Spawn(ctx, client, PruneSessionsJobParams{})",
+		`Name: "send-digest-job",`,
+		`Cron: "@daily",`,
+	} {
+		if !strings.Contains(schedule, want) {
+			t.Errorf("expected schedule to contain %q, got:\n%s", want, schedule)
+		}
+	}
+	if strings.Index(schedule, "prune-sessions-job") > strings.Index(schedule, "send-digest-job") {
+		t.Errorf("expected schedules in generation order, got:\n%s", schedule)
+	}
+
+	tasks := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "tasks.go"))
+	for _, want := range []string{"client.MustRegister(PruneSessionsJob)", "client.MustRegister(SendDigestJob)"} {
+		if !strings.Contains(tasks, want) {
+			t.Errorf("expected tasks to contain %q, got:\n%s", want, tasks)
+		}
+	}
+}
+
+func TestRunJobInvalidCron(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+
+	err := RunJob(JobInput{Name: "Cleanup", Cron: "*/5 * *", ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "invalid cron expression") {
+		t.Fatalf("expected invalid cron expression error, got %v", err)
+	}
+}
+
+func TestRunJobCronWithoutSchedules(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+	if err := os.Remove(filepath.Join(projectDir, "internal", "jobs", "schedule.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RunJob(JobInput{Name: "Cleanup", Cron: "@hourly", ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "var Schedules not found") {
+		t.Fatalf("expected missing Schedules error, got %v", err)
+	}
+
+	// Nothing is written when an edit fails.
+	tasks := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "tasks.go"))
+	if strings.Contains(tasks, "CleanupJob") {
+		t.Errorf("expected tasks to be untouched, got:\n%s", tasks)
+	}
+}
+
+func TestRunJobRequiresJobProcessor(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := RunJob(JobInput{Name: "Cleanup", ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "no job processor") {
+		t.Fatalf("expected no job processor error, got %v", err)
+	}
+}
+
+func TestRunJobInvalidParam(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupJobsPackage(t, projectDir)
+
+	err := RunJob(JobInput{Name: "Cleanup", Params: []string{"age:uuid"}, ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "unknown param type") {
+		t.Fatalf("expected unknown param type error, got %v", err)
+	}
+}
+
+func TestToSnake(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"SendWelcome", "send_welcome"},
+		{"send-welcome", "send_welcome"},
+		{"send_welcome", "send_welcome"},
+		{"HTTPPing", "http_ping"},
+		{"Resize2x", "resize2x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := toSnake(tt.input); got != tt.expected {
+				t.Errorf("toSnake(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJobRouteInstructions(t *testing.T) {
+	data := &jobData{Type: "SendWelcomeJob", Slug: "send_welcome"}
+
+	instructions := jobRouteInstructions("", "acme", data)
+	for _, want := range []string{`"acme/cmd/app/handlers"`, `api.POST("/jobs/send-welcome", handlers.HandleEnqueueSendWelcomeJob(s.jobs))`} {
+		if !strings.Contains(instructions, want) {
+			t.Errorf("expected instructions to contain %q, got:\n%s", want, instructions)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type MailerInput struct {
+	Name       string
+	Fields     []string
+	ProjectDir string
+	Quiet      bool
+}
+
+// mailerField is a field of the email data struct, with the label and templ
+// expression that display it.
+type mailerField struct {
+	Field
+	Label   string
+	Display string
+}
+
+// mailerData is the template data for a templ email.
+type mailerData struct {
+	Module string
+	// Type is the templ component, e.g. WelcomeEmail.
+	Type string
+	// Subject is the default subject line, e.g. "Password reset".
+	Subject string
+	// Slug names the email files, e.g. welcome_email.
+	Slug    string
+	Fields  []mailerField
+	HasTime bool
+	HasFmt  bool
+}
+
+// RunMailer generates a templ email and a typed data struct in
+// internal/smtp/emails, with a constructor rendering it into an smtp.Email.
+func RunMailer(input MailerInput) error {
+	module, err := readModule(input.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	if !hasMailer(input.ProjectDir) {
+		return fmt.Errorf("internal/smtp/render.go not found - this project has no SMTP")
+	}
+
+	data, err := newMailerData(input)
+	if err != nil {
+		return err
+	}
+	data.Module = module
+
+	emailsDir := filepath.Join(input.ProjectDir, "internal", "smtp", "emails")
+	targets := []generatedTarget{
+		{templateName: "mailer.go.tmpl", outputPath: filepath.Join(emailsDir, data.Slug+".go")},
+		{templateName: "mailer.templ.tmpl", outputPath: filepath.Join(emailsDir, data.Slug+".templ")},
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target.outputPath); err == nil {
+			rel, _ := filepath.Rel(input.ProjectDir, target.outputPath)
+			return fmt.Errorf("%s already exists", rel)
+		}
+	}
+
+	templates, err := parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	goFiles, err := renderTargets(templates, data, targets, input.ProjectDir, input.Quiet)
+	if err != nil {
+		return err
+	}
+	_ = runGenCommand("gofmt", append([]string{"-w", "-s"}, goFiles...), input.ProjectDir, true)
+	_ = runGenCommand("templ", []string{"generate", "-f", targets[1].outputPath}, input.ProjectDir, true)
+
+	if !input.Quiet {
+		fmt.Printf("\nRun templ generate, then render and send the email:\n\n")
+		fmt.Printf("    email, err := emails.New%s(ctx, to, emails.%sData{})\n", data.Type, data.Type)
+		fmt.Printf("    err = mailer.Send(ctx, email)\n")
+	}
+
+	return nil
+}
+
+func hasMailer(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "internal", "smtp", "render.go"))
+	return err == nil
+}
+
+func newMailerData(input MailerInput) (*mailerData, error) {
+	name := strings.TrimSpace(input.Name)
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid mailer name %q, expected a name like Welcome", input.Name)
+	}
+
+	base := strings.TrimSuffix(toTitle(name), "Email")
+	if base == "" {
+		return nil, fmt.Errorf("invalid mailer name %q, expected a name like Welcome", input.Name)
+	}
+	slug := toSnake(base)
+
+	// Email data is never stored, so fields parse like job params.
+	fields, err := parseJobParams(input.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &mailerData{
+		Type:    base + "Email",
+		Subject: humanize(slug),
+		Slug:    slug + "_email",
+	}
+	for _, f := range fields {
+		field := mailerField{Field: f, Label: humanize(toSnake(f.Name))}
+		switch f.GoType {
+		case "string":
+			field.Display = "data." + f.NameTitle
+		case "time.Time":
+			field.Display = "data." + f.NameTitle + `.Format("2006-01-02 15:04")`
+			data.HasTime = true
+		default:
+			field.Display = "fmt.Sprint(data." + f.NameTitle + ")"
+			data.HasFmt = true
+		}
+		data.Fields = append(data.Fields, field)
+	}
+
+	return data, nil
+}
+
+// humanize turns a snake_case name into a capitalized phrase, e.g.
+// password_reset into "Password reset".
+func humanize(snake string) string {
+	words := strings.ReplaceAll(snake, "_", " ")
+	return strings.ToUpper(words[:1]) + words[1:]
+}
@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupSMTPPackage(t *testing.T, projectDir string) {
+	t.Helper()
+
+	dir := filepath.Join(projectDir, "internal", "smtp")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "render.go"), []byte("package smtp\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunMailer(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupSMTPPackage(t, projectDir)
+
+	err := RunMailer(MailerInput{
+		Name:       "PasswordReset",
+		Fields:     []string{"name:string", "attempts:int", "expires_at:timestamp"},
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goPath := filepath.Join(projectDir, "internal", "smtp", "emails", "password_reset_email.go")
+	goFile := mustReadFile(t, goPath)
+	if _, err := parser.ParseFile(token.NewFileSet(), goPath, goFile, 0); err != nil {
+		t.Fatalf("generated mailer does not parse: %v", err)
+	}
+	for _, want := range []string{
+		`"acme/internal/smtp"`,
+		`"time"`,
+		"type PasswordResetEmailData struct",
+		"ExpiresAt time.Time",
+		"func NewPasswordResetEmail(ctx context.Context, to string, data PasswordResetEmailData) (smtp.Email, error)",
+		`smtp.NewEmail(ctx, to, "Password reset", PasswordResetEmail(data))`,
+	} {
+		if !strings.Contains(goFile, want) {
+			t.Errorf("expected mailer to contain %q, got:\n%s", want, goFile)
+		}
+	}
+
+	templ := mustReadFile(t, filepath.Join(projectDir, "internal", "smtp", "emails", "password_reset_email.templ"))
+	for _, want := range []string{
+		`import "fmt"`,
+		"templ PasswordResetEmail(data PasswordResetEmailData) {",
+		"<title>Password reset</title>",
+		"<p>Name: { data.Name }</p>",
+		"<p>Attempts: { fmt.Sprint(data.Attempts) }</p>",
+		`<p>Expires at: { data.ExpiresAt.Format("2006-01-02 15:04") }</p>`,
+	} {
+		if !strings.Contains(templ, want) {
+			t.Errorf("expected templ email to contain %q, got:\n%s", want, templ)
+		}
+	}
+
+	if err := RunMailer(MailerInput{Name: "PasswordResetEmail", ProjectDir: projectDir, Quiet: true}); err == nil {
+		t.Fatal("expected an error when the email already exists")
+	}
+}
+
+func TestRunMailerWithoutFields(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+	setupSMTPPackage(t, projectDir)
+
+	if err := RunMailer(MailerInput{Name: "welcome", ProjectDir: projectDir, Quiet: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	templ := mustReadFile(t, filepath.Join(projectDir, "internal", "smtp", "emails", "welcome_email.templ"))
+	if strings.Contains(templ, "import") {
+		t.Errorf("expected no imports without fields, got:\n%s", templ)
+	}
+}
+
+func TestRunMailerRequiresSMTP(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := RunMailer(MailerInput{Name: "Welcome", ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "no SMTP") {
+		t.Fatalf("expected no SMTP error, got %v", err)
+	}
+}
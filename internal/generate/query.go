@@ -0,0 +1,120 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var validQueryCommands = []string{"one", "many", "exec", "execresult", "execrows", "execlastid"}
+
+var queryNamePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+type QueryInput struct {
+	Plural     string
+	Name       string
+	SQL        string
+	Command    string
+	ProjectDir string
+	Quiet      bool
+}
+
+// RunQuery appends a named sqlc query to an existing
+// cmd/app/sql/queries/<plural>.sql file and regenerates the repo package.
+func RunQuery(input QueryInput) error {
+	cfg, err := LoadConfig(input.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	if !queryNamePattern.MatchString(input.Name) {
+		return fmt.Errorf("invalid query name %q: must be an exported Go identifier such as ListPublishedPosts", input.Name)
+	}
+
+	command := strings.TrimPrefix(strings.TrimSpace(input.Command), ":")
+	if command == "" {
+		command = "many"
+	}
+	if !slices.Contains(validQueryCommands, command) {
+		return fmt.Errorf("invalid query command %q. Must be one of: %s", input.Command, strings.Join(validQueryCommands, ", "))
+	}
+
+	query := strings.TrimSpace(input.SQL)
+	if query == "" {
+		return fmt.Errorf("query SQL cannot be empty")
+	}
+	if !strings.HasSuffix(query, ";") {
+		query += ";"
+	}
+
+	queriesDir := filepath.Join(input.ProjectDir, "cmd", "app", "sql", "queries")
+	relPath := filepath.Join("cmd", "app", "sql", "queries", strings.ToLower(input.Plural)+".sql")
+	content, err := os.ReadFile(filepath.Join(input.ProjectDir, relPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found - generate the resource first", relPath)
+		}
+		return fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+
+	// sqlc query names share one namespace across every queries file.
+	existing, err := findQuery(queriesDir, input.Name)
+	if err != nil {
+		return err
+	}
+	if existing != "" {
+		return fmt.Errorf("query %s already exists in %s", input.Name, filepath.Join("cmd", "app", "sql", "queries", existing))
+	}
+
+	updated := strings.TrimRight(string(content), "\n") + fmt.Sprintf("\n\n-- name: %s :%s\n%s\n", input.Name, command, query)
+	if err := os.WriteFile(filepath.Join(input.ProjectDir, relPath), []byte(updated), 0666); err != nil {
+		return fmt.Errorf("failed to write %s: %w", relPath, err)
+	}
+
+	if !input.Quiet {
+		fmt.Printf("  updated %s\n", relPath)
+	}
+
+	if err := runGenCommand("sqlc", []string{"generate", "-f", "sqlc.yaml"}, input.ProjectDir, input.Quiet); err != nil {
+		if !input.Quiet {
+			fmt.Println("  warning: sqlc generate failed. Run it manually: sqlc generate -f sqlc.yaml")
+		}
+	}
+
+	if !input.Quiet {
+		fmt.Printf("\nAdded %s (:%s). Call it as Query.%s from a service.\n", input.Name, command, input.Name)
+		if cfg.Tenancy && !strings.Contains(query, "tenant_id") {
+			fmt.Println("warning: this project is tenanted but the query does not filter by tenant_id.")
+		}
+	}
+
+	return nil
+}
+
+// findQuery returns the name of the queries file that declares name, or "".
+func findQuery(queriesDir string, name string) (string, error) {
+	pattern := regexp.MustCompile(`(?m)^--\s*name:\s*` + regexp.QuoteMeta(name) + `\s`)
+
+	entries, err := os.ReadDir(queriesDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read queries directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(queriesDir, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		if pattern.Match(content) {
+			return entry.Name(), nil
+		}
+	}
+
+	return "", nil
+}
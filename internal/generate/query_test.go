@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunQuery(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	queriesPath := filepath.Join(projectDir, "cmd", "app", "sql", "queries", "posts.sql")
+	existing := "-- name: GetPost :one\nSELECT * FROM posts WHERE id = $1 LIMIT 1;\n"
+	if err := os.WriteFile(queriesPath, []byte(existing), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RunQuery(QueryInput{
+		Plural:     "posts",
+		Name:       "ListPublishedPosts",
+		SQL:        "SELECT * FROM posts WHERE published = true ORDER BY id DESC",
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := existing + "\n-- name: ListPublishedPosts :many\nSELECT * FROM posts WHERE published = true ORDER BY id DESC;\n"
+	if got := mustReadFile(t, queriesPath); got != want {
+		t.Errorf("unexpected queries file:\n%s\nwant:\n%s", got, want)
+	}
+
+	err = RunQuery(QueryInput{
+		Plural:     "posts",
+		Name:       "PublishPost",
+		SQL:        "UPDATE posts SET published = true WHERE id = $1;",
+		Command:    ":exec",
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustReadFile(t, queriesPath); !strings.HasSuffix(got, "\n\n-- name: PublishPost :exec\nUPDATE posts SET published = true WHERE id = $1;\n") {
+		t.Errorf("expected PublishPost to be appended, got:\n%s", got)
+	}
+}
+
+func TestRunQueryDuplicate(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	queriesDir := filepath.Join(projectDir, "cmd", "app", "sql", "queries")
+	if err := os.WriteFile(filepath.Join(queriesDir, "posts.sql"), []byte("-- name: GetPost :one\nSELECT 1;\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(queriesDir, "comments.sql"), []byte("-- name: CountComments :one\nSELECT 1;\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// sqlc query names must be unique across every queries file.
+	for _, name := range []string{"GetPost", "CountComments"} {
+		err := RunQuery(QueryInput{Plural: "posts", Name: name, SQL: "SELECT 1", ProjectDir: projectDir, Quiet: true})
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("expected duplicate error for %s, got %v", name, err)
+		}
+	}
+}
+
+func TestRunQueryValidation(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	tests := []struct {
+		desc  string
+		input QueryInput
+		want  string
+	}{
+		{"missing file", QueryInput{Plural: "posts", Name: "GetPost", SQL: "SELECT 1"}, "not found"},
+		{"unexported name", QueryInput{Plural: "posts", Name: "getPost", SQL: "SELECT 1"}, "invalid query name"},
+		{"unknown command", QueryInput{Plural: "posts", Name: "GetPost", SQL: "SELECT 1", Command: "batch"}, "invalid query command"},
+		{"empty sql", QueryInput{Plural: "posts", Name: "GetPost", SQL: "  "}, "cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.input.ProjectDir = projectDir
+			tt.input.Quiet = true
+
+			err := RunQuery(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -14,16 +14,31 @@ type Resource struct {
 	ModuleName string
 	Database   string
 	Fields     []Field
+
+	// Audited adds created_by/updated_by columns and a <plural>_history table
+	// that records before/after snapshots of every update and delete.
+	Audited bool
+
+	// Tenanted adds a tenant_id column that every query filters on. It is set
+	// for projects generated with tenancy.
+	Tenanted bool
 }
 
 type Field struct {
-	Name      string
-	NameTitle string
-	Type      string
-	SQLType   string
-	GoType    string
+	Name       string
+	NameTitle  string
+	Type       string
+	SQLType    string
+	GoType     string
+	Searchable bool
 }
 
+// fieldModifierSearchable marks a text field for inclusion in the resource's
+// full-text search index.
+const fieldModifierSearchable = "searchable"
+
+const validFieldTypesHelp = "Valid types: string, text, int, bigint, bool, float, timestamp"
+
 func NewResource(name string, plural string, fields []Field, cfg *ProjectConfig) *Resource {
 	return &Resource{
 		Name:       strings.ToLower(name),
@@ -32,15 +47,106 @@ func NewResource(name string, plural string, fields []Field, cfg *ProjectConfig)
 		ModuleName: cfg.Module,
 		Database:   cfg.Database,
 		Fields:     fields,
+		Tenanted:   cfg.Tenancy,
 	}
 }
 
+// identifierSQLTypes maps each database to the column type used for the
+// tenant_id column and the created_by, updated_by and changed_by audit columns.
+var identifierSQLTypes = map[string]string{
+	"postgres": "TEXT",
+	"mysql":    "VARCHAR(255)",
+	"mariadb":  "VARCHAR(255)",
+	"sqlite3":  "TEXT",
+}
+
+// CreateFields returns the columns written by the Create query: the tenant
+// column, the resource's fields, then the audit columns.
+func (r *Resource) CreateFields() []Field {
+	var fields []Field
+	if r.Tenanted {
+		fields = append(fields, Field{Name: "tenant_id", NameTitle: "TenantID", Type: "string", SQLType: r.IdentifierSQLType(), GoType: "string"})
+	}
+	fields = append(fields, r.Fields...)
+	if r.Audited {
+		fields = append(fields, r.auditField("created_by"), r.auditField("updated_by"))
+	}
+	return fields
+}
+
+// UpdateFields returns the columns written by the Update query.
+func (r *Resource) UpdateFields() []Field {
+	if !r.Audited {
+		return r.Fields
+	}
+	return append(append([]Field{}, r.Fields...), r.auditField("updated_by"))
+}
+
+// Columns returns the names of every column of the resource's table, in
+// declaration order.
+func (r *Resource) Columns() []string {
+	columns := []string{"id"}
+	if r.Tenanted {
+		columns = append(columns, "tenant_id")
+	}
+	for _, f := range r.Fields {
+		columns = append(columns, f.Name)
+	}
+	if r.Audited {
+		columns = append(columns, "created_by", "updated_by")
+	}
+	return append(columns, "created_at", "updated_at")
+}
+
+// IdentifierSQLType returns the column type of the tenant and audit actor
+// columns.
+func (r *Resource) IdentifierSQLType() string {
+	return identifierSQLTypes[r.Database]
+}
+
+// HistoryTable returns the name of the audit history table.
+func (r *Resource) HistoryTable() string {
+	return r.PluralName + "_history"
+}
+
+// HistoryModel returns the name of the sqlc model generated for the history
+// table.
+func (r *Resource) HistoryModel() string {
+	return toTitle(r.HistoryTable())
+}
+
+func (r *Resource) auditField(name string) Field {
+	return Field{
+		Name:      name,
+		NameTitle: toTitle(name),
+		Type:      "string",
+		SQLType:   r.IdentifierSQLType(),
+		GoType:    "*string",
+	}
+}
+
+// SearchFields returns the fields included in the full-text search index.
+func (r *Resource) SearchFields() []Field {
+	var fields []Field
+	for _, f := range r.Fields {
+		if f.Searchable {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// HasSearch reports whether the resource has any searchable fields.
+func (r *Resource) HasSearch() bool {
+	return len(r.SearchFields()) > 0
+}
+
 func ParseFields(rawFields []string, database string) ([]Field, error) {
 	fields := make([]Field, 0, len(rawFields))
 	for _, raw := range rawFields {
-		parts := strings.SplitN(raw, ":", 2)
-		if len(parts) != 2 || parts[1] == "" {
-			return nil, fmt.Errorf("invalid field %q, expected name:type format (e.g. title:string)\nValid types: string, text, int, bigint, bool, float, timestamp", raw)
+		parts := strings.Split(raw, ":")
+		if len(parts) < 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid field %q, expected name:type format (e.g. title:string)\n%s", raw, validFieldTypesHelp)
 		}
 
 		name := parts[0]
@@ -52,7 +158,20 @@ func ParseFields(rawFields []string, database string) ([]Field, error) {
 
 		mapping, ok := typeMapping[typeName]
 		if !ok {
-			return nil, fmt.Errorf("unknown field type %q in %q\nValid types: string, text, int, bigint, bool, float, timestamp", typeName, raw)
+			return nil, fmt.Errorf("unknown field type %q in %q\n%s", typeName, raw, validFieldTypesHelp)
+		}
+
+		var searchable bool
+		for _, modifier := range parts[2:] {
+			switch modifier {
+			case fieldModifierSearchable:
+				if typeName != "string" && typeName != "text" {
+					return nil, fmt.Errorf("field %q cannot be searchable: only string and text fields support full-text search", name)
+				}
+				searchable = true
+			default:
+				return nil, fmt.Errorf("unknown field modifier %q in %q\nValid modifiers: %s", modifier, raw, fieldModifierSearchable)
+			}
 		}
 
 		sqlType, ok := mapping.SQLTypes[database]
@@ -61,11 +180,12 @@ func ParseFields(rawFields []string, database string) ([]Field, error) {
 		}
 
 		fields = append(fields, Field{
-			Name:      name,
-			NameTitle: toTitle(name),
-			Type:      typeName,
-			SQLType:   sqlType,
-			GoType:    mapping.GoType,
+			Name:       name,
+			NameTitle:  toTitle(name),
+			Type:       typeName,
+			SQLType:    sqlType,
+			GoType:     mapping.GoType,
+			Searchable: searchable,
 		})
 	}
 	return fields, nil
@@ -66,6 +66,42 @@ func TestParseFieldsEmptyName(t *testing.T) {
 	}
 }
 
+func TestParseFieldsSearchable(t *testing.T) {
+	fields, err := ParseFields([]string{"title:string:searchable", "body:text:searchable", "views:int"}, "postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !fields[0].Searchable || !fields[1].Searchable {
+		t.Errorf("expected title and body to be searchable: %+v", fields)
+	}
+	if fields[2].Searchable {
+		t.Errorf("expected views not to be searchable: %+v", fields[2])
+	}
+
+	r := NewResource("article", "articles", fields, &ProjectConfig{Module: "acme", Database: "postgres"})
+	if !r.HasSearch() {
+		t.Error("expected resource to have search")
+	}
+	if got := len(r.SearchFields()); got != 2 {
+		t.Errorf("expected 2 search fields, got %d", got)
+	}
+}
+
+func TestParseFieldsSearchableNonText(t *testing.T) {
+	_, err := ParseFields([]string{"views:int:searchable"}, "postgres")
+	if err == nil {
+		t.Fatal("expected error for searchable int field")
+	}
+}
+
+func TestParseFieldsUnknownModifier(t *testing.T) {
+	_, err := ParseFields([]string{"title:string:indexed"}, "postgres")
+	if err == nil {
+		t.Fatal("expected error for unknown modifier")
+	}
+}
+
 func TestNewResource(t *testing.T) {
 	cfg := &ProjectConfig{Module: "acme", Database: "postgres"}
 	fields := []Field{{Name: "title", NameTitle: "Title", Type: "string", SQLType: "TEXT", GoType: "string"}}
@@ -0,0 +1,234 @@
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// serviceData is the template data for a service that wraps the repo methods
+// sqlc generated for an existing queries file.
+type serviceData struct {
+	NameTitle  string
+	PluralName string
+	Tenanted   bool
+	// StdImports and Imports are quoted import paths, split into the standard
+	// library group and everything else.
+	StdImports []string
+	Imports    []string
+	Methods    []serviceMethod
+}
+
+type serviceMethod struct {
+	Name    string
+	Params  string
+	Args    string
+	Results string
+}
+
+// RunService generates a service skeleton whose methods delegate to the repo
+// methods already generated for cmd/app/sql/queries/<plural>.sql.
+func RunService(input GenerateInput) error {
+	cfg, err := LoadConfig(input.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	if !namePattern.MatchString(input.Name) {
+		return fmt.Errorf("invalid service name %q, expected a name like report", input.Name)
+	}
+	if !namePattern.MatchString(input.Plural) {
+		return fmt.Errorf("invalid plural %q, expected a name like reports", input.Plural)
+	}
+
+	name := strings.ToLower(input.Name)
+	plural := strings.ToLower(input.Plural)
+	outputPath := filepath.Join(input.ProjectDir, "cmd", "app", "service", name+"_service.go")
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists", filepath.Join("cmd", "app", "service", name+"_service.go"))
+	}
+
+	repoPath := filepath.Join("cmd", "app", "repo", plural+".sql.go")
+	data, err := newServiceData(filepath.Join(input.ProjectDir, repoPath), cfg)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found - add queries for %s and run sqlc generate first", repoPath, plural)
+		}
+		return err
+	}
+	data.NameTitle = toTitle(input.Name)
+	data.PluralName = plural
+
+	templates, err := parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	goFiles, err := renderTargets(templates, data, []generatedTarget{
+		{templateName: "service_wrapper.go.tmpl", outputPath: outputPath},
+	}, input.ProjectDir, input.Quiet)
+	if err != nil {
+		return err
+	}
+	_ = runGenCommand("gofmt", append([]string{"-w", "-s"}, goFiles...), input.ProjectDir, true)
+
+	if !input.Quiet {
+		fmt.Printf("\nGenerated %sService with %d method(s) from %s.\n", data.NameTitle, len(data.Methods), repoPath)
+		if data.Tenanted {
+			fmt.Println("This project is tenanted: set TenantID from tenant.FromContext before calling the queries.")
+		}
+		fmt.Printf("\n%s\n", buildRouterInstructions(readRouter(input.ProjectDir), []routerLine{
+			{
+				code:       fmt.Sprintf("%sService := service.New%sService(s.db)", name, data.NameTitle),
+				importPath: cfg.Module + "/cmd/app/service",
+			},
+		}, "this service"))
+	}
+
+	return nil
+}
+
+// newServiceData reads the exported *Queries methods from a sqlc output file.
+func newServiceData(repoFile string, cfg *ProjectConfig) (*serviceData, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, repoFile, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	importPaths := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		importPaths[name] = importPath
+	}
+
+	used := map[string]bool{"context": true, "database/sql": true}
+	var methods []serviceMethod
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || !fn.Name.IsExported() || !isQueriesReceiver(fn.Recv) {
+			continue
+		}
+
+		qualify := func(expr ast.Expr) string {
+			return repoTypeString(expr, importPaths, used)
+		}
+
+		var params, args []string
+		for i, field := range fn.Type.Params.List {
+			typ := qualify(field.Type)
+			if len(field.Names) == 0 {
+				field.Names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+			}
+			for _, n := range field.Names {
+				params = append(params, n.Name+" "+typ)
+				if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+					args = append(args, n.Name+"...")
+					continue
+				}
+				args = append(args, n.Name)
+			}
+		}
+
+		var results []string
+		if fn.Type.Results != nil {
+			for _, field := range fn.Type.Results.List {
+				typ := qualify(field.Type)
+				for range max(len(field.Names), 1) {
+					results = append(results, typ)
+				}
+			}
+		}
+
+		resultList := strings.Join(results, ", ")
+		if len(results) > 1 {
+			resultList = "(" + resultList + ")"
+		}
+
+		methods = append(methods, serviceMethod{
+			Name:    fn.Name.Name,
+			Params:  strings.Join(params, ", "),
+			Args:    strings.Join(args, ", "),
+			Results: resultList,
+		})
+	}
+
+	data := &serviceData{Tenanted: cfg.Tenancy, Methods: methods}
+	data.Imports = append(data.Imports, strconv.Quote(cfg.Module+"/cmd/app/repo"))
+	for importPath := range used {
+		if isStdImport(importPath) {
+			data.StdImports = append(data.StdImports, strconv.Quote(importPath))
+		} else {
+			data.Imports = append(data.Imports, strconv.Quote(importPath))
+		}
+	}
+	sort.Strings(data.StdImports)
+	sort.Strings(data.Imports)
+
+	return data, nil
+}
+
+func isQueriesReceiver(recv *ast.FieldList) bool {
+	if len(recv.List) != 1 {
+		return false
+	}
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Queries"
+}
+
+// repoTypeString renders a type from the repo package as it must be spelled
+// in the service package, recording the import paths it refers to.
+func repoTypeString(expr ast.Expr, importPaths map[string]string, used map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return "repo." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		if importPath, ok := importPaths[pkg]; ok {
+			used[importPath] = true
+		}
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + repoTypeString(t.X, importPaths, used)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + repoTypeString(t.Elt, importPaths, used)
+		}
+		return "[" + t.Len.(*ast.BasicLit).Value + "]" + repoTypeString(t.Elt, importPaths, used)
+	case *ast.MapType:
+		return "map[" + repoTypeString(t.Key, importPaths, used) + "]" + repoTypeString(t.Value, importPaths, used)
+	case *ast.Ellipsis:
+		return "..." + repoTypeString(t.Elt, importPaths, used)
+	case *ast.InterfaceType:
+		return "interface{}"
+	default:
+		return types.ExprString(expr)
+	}
+}
+
+func isStdImport(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// repoPostsSQL mirrors the shape of a sqlc output file.
+const repoPostsSQL = `// Code generated by sqlc. DO NOT EDIT.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const getPost = "SELECT id, title, created_at FROM posts WHERE id = $1 LIMIT 1"
+
+func (q *Queries) GetPost(ctx context.Context, id int64) (Post, error) {
+	row := q.queryRow(ctx, q.getPostStmt, getPost, id)
+	var i Post
+	err := row.Scan(&i.ID, &i.Title, &i.CreatedAt)
+	return i, err
+}
+
+type ListPostsSinceParams struct {
+	CreatedAt time.Time
+	RowLimit  int32
+}
+
+func (q *Queries) ListPostsSince(ctx context.Context, arg ListPostsSinceParams) ([]Post, error) {
+	return nil, nil
+}
+
+func (q *Queries) TouchPosts(ctx context.Context, since time.Time) (sql.Result, error) {
+	return nil, nil
+}
+
+func (q *Queries) DeletePost(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+`
+
+func TestRunService(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	repoDir := filepath.Join(projectDir, "cmd", "app", "repo")
+	if err := os.MkdirAll(repoDir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "posts.sql.go"), []byte(repoPostsSQL), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RunService(GenerateInput{
+		Name:       "report",
+		Plural:     "posts",
+		ProjectDir: projectDir,
+		Quiet:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(projectDir, "cmd", "app", "service", "report_service.go")
+	svc := mustReadFile(t, path)
+	if _, err := parser.ParseFile(token.NewFileSet(), path, svc, 0); err != nil {
+		t.Fatalf("generated service does not parse: %v\n%s", err, svc)
+	}
+
+	for _, want := range []string{
+		`"time"`,
+		`"acme/cmd/app/repo"`,
+		"func NewReportService(database *sql.DB) *ReportService",
+		"func (s *ReportService) GetPost(ctx context.Context, id int64) (repo.Post, error) {\n\treturn s.Query.GetPost(ctx, id)",
+		"func (s *ReportService) ListPostsSince(ctx context.Context, arg repo.ListPostsSinceParams) ([]repo.Post, error)",
+		"func (s *ReportService) TouchPosts(ctx context.Context, since time.Time) (sql.Result, error)",
+		"func (s *ReportService) DeletePost(ctx context.Context, id int64) error {",
+	} {
+		if !strings.Contains(svc, want) {
+			t.Errorf("expected service to contain %q, got:\n%s", want, svc)
+		}
+	}
+	if strings.Contains(svc, "queryRow") {
+		t.Errorf("unexported repo methods should not be wrapped, got:\n%s", svc)
+	}
+}
+
+func TestRunServiceMissingRepo(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	err := RunService(GenerateInput{Name: "report", Plural: "posts", ProjectDir: projectDir, Quiet: true})
+	if err == nil || !strings.Contains(err.Error(), "run sqlc generate first") {
+		t.Fatalf("expected missing repo error, got %v", err)
+	}
+}
+
+func TestRunServiceInvalidName(t *testing.T) {
+	projectDir := t.TempDir()
+	setupProjectDir(t, projectDir, "postgres")
+
+	for _, input := range []GenerateInput{
+		{Name: "../../x", Plural: "posts"},
+		{Name: "my stats", Plural: "posts"},
+		{Name: "1stats", Plural: "posts"},
+		{Name: "report", Plural: "../posts"},
+	} {
+		input.ProjectDir = projectDir
+		input.Quiet = true
+		err := RunService(input)
+		if err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("expected an invalid name error for %q/%q, got %v", input.Name, input.Plural, err)
+		}
+	}
+}
@@ -26,10 +26,15 @@ type Config struct {
 	KeyValueStore KeyValueStore
 	JobProcessor  JobProcessor
 	Templ         bool
+	Tenancy       bool
+	// DevS3 adds a MinIO container to devenv.yaml and points the S3_*
+	// variables in .env at it.
+	DevS3 bool
 
 	DevDBDashboard      bool
 	DevMailboxDashboard bool
 	DevStorageDashboard bool
+	DevJobsDashboard    bool
 }
 
 // Generate creates the project files without running any external commands.
@@ -64,7 +69,7 @@ func Finalize(cfg *Config) error {
 		}
 	}
 
-	printSuccessMessage(project.Name, project.Database, project.HasKeyValueStore(), cfg.Quiet)
+	printSuccessMessage(project.Name, project.Database, project.HasDevEnv(), cfg.Quiet)
 
 	return nil
 }
@@ -184,24 +189,44 @@ func normalizeConfig(cfg *Config) error {
 		cfg.ContainerRuntime = ContainerRuntimePodman
 	}
 
-	// Job processing is currently only supported on Postgres.
-	if cfg.Database != DatabasePostgres {
-		cfg.JobProcessor = JobProcessorNone
+	// Absurd is Postgres-only and the database processor covers the other
+	// engines. Postgres projects asking for the database processor get Absurd,
+	// which is itself backed by the database.
+	switch cfg.JobProcessor {
+	case JobProcessorAbsurd:
+		if cfg.Database != DatabasePostgres {
+			cfg.JobProcessor = JobProcessorNone
+		}
+	case JobProcessorDatabase:
+		if cfg.Database == DatabaseNone {
+			cfg.JobProcessor = JobProcessorNone
+		} else if cfg.Database == DatabasePostgres {
+			cfg.JobProcessor = JobProcessorAbsurd
+		}
+	case JobProcessorKeyValueStore:
+		if cfg.KeyValueStore == KeyValueStoreNone {
+			cfg.JobProcessor = JobProcessorNone
+		}
 	}
 
 	// Dashboards are only valid when their parent feature is enabled.
 	if cfg.Database == DatabaseNone {
 		cfg.DevDBDashboard = false
+		cfg.Tenancy = false
 	}
 	if !cfg.SMTP {
 		cfg.DevMailboxDashboard = false
 	}
 	if !cfg.Storage {
 		cfg.DevStorageDashboard = false
+		cfg.DevS3 = false
+	}
+	if cfg.JobProcessor == JobProcessorNone {
+		cfg.DevJobsDashboard = false
 	}
 
 	// Dev dashboards require Templ for their UIs.
-	if cfg.DevDBDashboard || cfg.DevMailboxDashboard || cfg.DevStorageDashboard {
+	if cfg.DevDBDashboard || cfg.DevMailboxDashboard || cfg.DevStorageDashboard || cfg.DevJobsDashboard {
 		cfg.Templ = true
 	}
 
@@ -239,7 +264,7 @@ func normalizeConfig(cfg *Config) error {
 	return nil
 }
 
-func printSuccessMessage(projectName string, database Database, hasKeyValueStore bool, quiet bool) {
+func printSuccessMessage(projectName string, database Database, hasDevEnv bool, quiet bool) {
 	if quiet {
 		return
 	}
@@ -251,7 +276,7 @@ Run your new project:
 
   $ cd %s`, projectName, projectName)
 
-	if database == DatabasePostgres || database == DatabaseMySQL || database == DatabaseMariaDB || hasKeyValueStore {
+	if hasDevEnv {
 		successMessage += `
   $ make devenv.up # Start the dev environment`
 	}
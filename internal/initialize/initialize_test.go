@@ -407,8 +407,11 @@ func jobsFiles(projectDir string) []string {
 	return []string{
 		filepath.Join(projectDir, "internal", "jobs", "jobs.go"),
 		filepath.Join(projectDir, "internal", "jobs", "tasks.go"),
+		filepath.Join(projectDir, "internal", "jobs", "schedule.go"),
+		filepath.Join(projectDir, "internal", "jobs", "worker.go"),
 		filepath.Join(projectDir, "internal", "jobs", "absurd.sql"),
 		filepath.Join(projectDir, "cmd", "app", "handlers", "jobs_handler.go"),
+		filepath.Join(projectDir, "cmd", "worker", "main.go"),
 	}
 }
 
@@ -426,27 +429,729 @@ func TestGenerateJobs(t *testing.T) {
 			t.Fatalf("jobs file not created at %s", f)
 		}
 	}
+	for _, f := range queueFiles(projectDir) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("queue file should not exist with absurd at %s", f)
+		}
+	}
+
+	// The app must wire the jobs client and expose the example route.
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	if !strings.Contains(router, "HandleEnqueueExampleJob") {
+		t.Fatal("router should register the example job route when jobs are enabled")
+	}
+
+	// The migrator must install the absurd schema.
+	migrator := mustReadFile(t, filepath.Join(projectDir, "cmd", "migrator", "main.go"))
+	if !strings.Contains(migrator, "jobs.Setup") {
+		t.Fatal("migrator should set up jobs when jobs are enabled")
+	}
+
+	// The app must register tasks and run the worker in-process unless
+	// JOBS_IN_PROCESS_WORKER disables it.
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	if !strings.Contains(main, "jobs.Register") {
+		t.Fatal("app main should register jobs when jobs are enabled")
+	}
+	if !strings.Contains(main, "vars.JobsInProcessWorker") {
+		t.Fatal("app main should pass the in-process worker toggle to the server")
+	}
+	server := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "server.go"))
+	if !strings.Contains(server, "if s.inProcessWorker {") || !strings.Contains(server, "jobs.Run(ctx") {
+		t.Fatal("app server should run the worker when the in-process worker is enabled")
+	}
+	if !strings.Contains(server, "jobs.NewScheduler") {
+		t.Fatal("app server should run the scheduler when jobs are enabled")
+	}
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	if !strings.Contains(env, "JOBS_IN_PROCESS_WORKER=true") {
+		t.Fatal(".env should enable the in-process worker")
+	}
+
+	// The standalone worker registers tasks and runs the worker and scheduler.
+	worker := mustReadFile(t, filepath.Join(projectDir, "cmd", "worker", "main.go"))
+	for _, want := range []string{"jobs.Register(jobsClient)", "jobs.NewScheduler(database,", "jobs.Run(ctx"} {
+		if !strings.Contains(worker, want) {
+			t.Fatalf("worker main should contain %q", want)
+		}
+	}
+	if strings.Contains(worker, "gin") {
+		t.Fatal("worker should not serve HTTP")
+	}
+
+	makefile := mustReadFile(t, filepath.Join(projectDir, "Makefile"))
+	if !strings.Contains(makefile, "run.worker:") || !strings.Contains(makefile, "--build-arg ENTRYPOINT=$(WORKER_NAME)") {
+		t.Fatal("Makefile should have worker targets")
+	}
+	dockerfile := mustReadFile(t, filepath.Join(projectDir, "Dockerfile"))
+	if !strings.Contains(dockerfile, "ARG ENTRYPOINT=app") || !strings.Contains(dockerfile, "./cmd/${ENTRYPOINT}") {
+		t.Fatal("Dockerfile should choose the entrypoint with a build arg")
+	}
+
+	if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateNoJobs(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabasePostgres,
+		Git:          false,
+		JobProcessor: initialize.JobProcessorNone,
+	})
+
+	for _, f := range jobsFiles(projectDir) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("jobs file should not exist at %s", f)
+		}
+	}
+
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	if strings.Contains(router, "HandleEnqueueExampleJob") {
+		t.Fatal("router should not reference jobs when jobs are disabled")
+	}
+
+	dockerfile := mustReadFile(t, filepath.Join(projectDir, "Dockerfile"))
+	if strings.Contains(dockerfile, "ENTRYPOINT") {
+		t.Fatal("Dockerfile should only build the app when jobs are disabled")
+	}
+}
+
+func TestGenerateJobsRequiresPostgres(t *testing.T) {
+	// Absurd is Postgres-only; it must be forced off for other databases even
+	// when explicitly requested.
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabaseSQLite3,
+		Git:          false,
+		JobProcessor: initialize.JobProcessorAbsurd,
+	})
+
+	for _, f := range jobsFiles(projectDir) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("jobs file should not exist for non-postgres database at %s", f)
+		}
+	}
+}
+
+func queueFiles(projectDir string) []string {
+	return []string{
+		filepath.Join(projectDir, "internal", "jobs", "queue", "queue.go"),
+		filepath.Join(projectDir, "internal", "jobs", "queue", "inspect.go"),
+		filepath.Join(projectDir, "internal", "jobs", "queue", "store_sql.go"),
+	}
+}
+
+func TestGenerateDatabaseJobs(t *testing.T) {
+	for _, database := range []initialize.Database{
+		initialize.DatabaseSQLite3,
+		initialize.DatabaseMySQL,
+		initialize.DatabaseMariaDB,
+	} {
+		t.Run(database.String(), func(t *testing.T) {
+			projectDir := generateProject(t, initialize.Config{
+				Quiet:        true,
+				Name:         "acme",
+				Database:     database,
+				Git:          false,
+				JobProcessor: initialize.JobProcessorDatabase,
+			})
+
+			for _, f := range append(queueFiles(projectDir), jobsFiles(projectDir)...) {
+				_, err := os.Stat(f)
+				if strings.HasSuffix(f, "absurd.sql") {
+					if !os.IsNotExist(err) {
+						t.Fatalf("absurd schema should not exist with the database processor at %s", f)
+					}
+					continue
+				}
+				if os.IsNotExist(err) {
+					t.Fatalf("jobs file not created at %s", f)
+				}
+			}
+
+			// Tasks use the queue package and handlers only depend on jobs.
+			tasks := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "tasks.go"))
+			if !strings.Contains(tasks, "queue.Task(") || !strings.Contains(tasks, "queue.Step(") {
+				t.Fatal("tasks should be defined with the queue package")
+			}
+			for _, f := range []string{
+				filepath.Join(projectDir, "internal", "jobs", "jobs.go"),
+				filepath.Join(projectDir, "internal", "jobs", "tasks.go"),
+				filepath.Join(projectDir, "internal", "jobs", "schedule.go"),
+				filepath.Join(projectDir, "cmd", "app", "handlers", "jobs_handler.go"),
+				filepath.Join(projectDir, "cmd", "app", "server.go"),
+			} {
+				if strings.Contains(mustReadFile(t, f), "absurd") {
+					t.Fatalf("%s should not reference absurd with the database processor", f)
+				}
+			}
+			handler := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "handlers", "jobs_handler.go"))
+			if !strings.Contains(handler, "HandleEnqueueExampleJob(client *jobs.Client)") {
+				t.Fatal("job handler should take a *jobs.Client")
+			}
+
+			// A tick is claimed and its task enqueued in one transaction.
+			schedule := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "schedule.go"))
+			if !strings.Contains(schedule, "entry.Spawn(queue.WithTx(ctx, tx), s.client)") || strings.Contains(schedule, "s.release(") {
+				t.Fatal("scheduler should claim ticks and spawn tasks in one transaction")
+			}
+			_, err := os.Stat(filepath.Join(projectDir, "internal", "jobs", "schedule_test.go"))
+			if (database == initialize.DatabaseSQLite3) != (err == nil) {
+				t.Fatalf("scheduler tests should only be generated for sqlite3, got %v", err)
+			}
+			for _, name := range []string{"queue_test.go", "store_sql_test.go"} {
+				_, err := os.Stat(filepath.Join(projectDir, "internal", "jobs", "queue", name))
+				if (database == initialize.DatabaseSQLite3) != (err == nil) {
+					t.Fatalf("queue tests should only be generated for sqlite3, got %v", err)
+				}
+			}
+
+			store := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "queue", "store_sql.go"))
+			skipLocked := strings.Contains(store, "FOR UPDATE SKIP LOCKED")
+			if database == initialize.DatabaseSQLite3 && skipLocked {
+				t.Fatal("sqlite store should not lock rows")
+			}
+			if database != initialize.DatabaseSQLite3 && !skipLocked {
+				t.Fatal("mysql store should claim jobs with SKIP LOCKED")
+			}
+
+			migrator := mustReadFile(t, filepath.Join(projectDir, "cmd", "migrator", "main.go"))
+			if !strings.Contains(migrator, "jobs.Setup") {
+				t.Fatal("migrator should set up jobs when jobs are enabled")
+			}
+
+			if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGenerateDatabaseJobsOnPostgresUsesAbsurd(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabasePostgres,
+		Git:          false,
+		JobProcessor: initialize.JobProcessorDatabase,
+	})
+
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "jobs", "absurd.sql")); os.IsNotExist(err) {
+		t.Fatal("postgres projects should use absurd for database-backed jobs")
+	}
+	for _, f := range queueFiles(projectDir) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("queue file should not exist for postgres at %s", f)
+		}
+	}
+}
+
+func TestGenerateDatabaseJobsRequiresDatabase(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabaseNone,
+		Git:          false,
+		JobProcessor: initialize.JobProcessorDatabase,
+	})
+
+	for _, f := range append(queueFiles(projectDir), jobsFiles(projectDir)...) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("jobs file should not exist without a database at %s", f)
+		}
+	}
+}
+
+func TestGenerateKeyValueStoreJobs(t *testing.T) {
+	for _, kvs := range []initialize.KeyValueStore{
+		initialize.KeyValueStoreRedis,
+		initialize.KeyValueStoreValkey,
+	} {
+		t.Run(string(kvs), func(t *testing.T) {
+			projectDir := generateProject(t, initialize.Config{
+				Quiet:         true,
+				Name:          "acme",
+				Database:      initialize.DatabaseNone,
+				KeyValueStore: kvs,
+				Git:           false,
+				JobProcessor:  initialize.JobProcessorKeyValueStore,
+			})
+
+			for _, f := range []string{
+				filepath.Join(projectDir, "internal", "jobs", "jobs.go"),
+				filepath.Join(projectDir, "internal", "jobs", "tasks.go"),
+				filepath.Join(projectDir, "internal", "jobs", "schedule.go"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "queue.go"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "inspect.go"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "store_kv.go"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "queue_test.go"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "store_kv_test.go"),
+			} {
+				if _, err := os.Stat(f); os.IsNotExist(err) {
+					t.Fatalf("jobs file not created at %s", f)
+				}
+			}
+			for _, f := range []string{
+				filepath.Join(projectDir, "internal", "jobs", "absurd.sql"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "store_sql.go"),
+				filepath.Join(projectDir, "internal", "jobs", "queue", "store_sql_test.go"),
+			} {
+				if _, err := os.Stat(f); !os.IsNotExist(err) {
+					t.Fatalf("database jobs file should not exist with the kvs processor at %s", f)
+				}
+			}
+
+			jobs := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "jobs.go"))
+			if strings.Contains(jobs, "func Setup(") {
+				t.Fatal("kvs jobs should not need a setup step")
+			}
+
+			server := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "server.go"))
+			if !strings.Contains(server, "jobs.NewScheduler(s."+string(kvs)+",") {
+				t.Fatalf("scheduler should claim ticks in %s", kvs)
+			}
+
+			// Scripts take every key through KEYS, which share a hash tag.
+			store := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "queue", "store_kv.go"))
+			if !strings.Contains(store, `"jobs:{" + opts.QueueName + "}:"`) || strings.Contains(store, "ARGV[3] .. 'job:'") {
+				t.Fatal("kv store keys should share the queue's hash tag")
+			}
+
+			if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGenerateKeyValueStoreJobsWithDatabase(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:         true,
+		Name:          "acme",
+		Database:      initialize.DatabasePostgres,
+		KeyValueStore: initialize.KeyValueStoreRedis,
+		Git:           false,
+		JobProcessor:  initialize.JobProcessorKeyValueStore,
+	})
+
+	migrator := mustReadFile(t, filepath.Join(projectDir, "cmd", "migrator", "main.go"))
+	if strings.Contains(migrator, "jobs.Setup") {
+		t.Fatal("migrator should not set up jobs stored in the key-value store")
+	}
+
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	if !strings.Contains(main, "Redis:     rdb,") {
+		t.Fatal("jobs client should use the redis client")
+	}
+}
+
+func TestGenerateKeyValueStoreJobsRequiresKeyValueStore(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabasePostgres,
+		Git:          false,
+		JobProcessor: initialize.JobProcessorKeyValueStore,
+	})
+
+	for _, f := range jobsFiles(projectDir) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("jobs file should not exist without a key-value store at %s", f)
+		}
+	}
+}
+
+func devJobsFiles(projectDir string) []string {
+	return []string{
+		filepath.Join(projectDir, "internal", "jobs", "dev_jobs.go"),
+		filepath.Join(projectDir, "internal", "jobs", "dev_jobs_handler.go"),
+		filepath.Join(projectDir, "internal", "jobs", "dev_jobs_queries.go"),
+		filepath.Join(projectDir, "internal", "jobs", "dev_jobs_layout.templ"),
+		filepath.Join(projectDir, "internal", "jobs", "dev_jobs_list.templ"),
+		filepath.Join(projectDir, "internal", "jobs", "dev_jobs_show.templ"),
+	}
+}
+
+func TestGenerateDevJobsDashboard(t *testing.T) {
+	tests := []struct {
+		name          string
+		database      initialize.Database
+		keyValueStore initialize.KeyValueStore
+		jobProcessor  initialize.JobProcessor
+		dashboardArg  string
+	}{
+		{"absurd", initialize.DatabasePostgres, initialize.KeyValueStoreNone, initialize.JobProcessorAbsurd, "database"},
+		{"database", initialize.DatabaseSQLite3, initialize.KeyValueStoreNone, initialize.JobProcessorDatabase, "jobsClient"},
+		{"kvs", initialize.DatabaseNone, initialize.KeyValueStoreRedis, initialize.JobProcessorKeyValueStore, "jobsClient"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := generateProject(t, initialize.Config{
+				Quiet:            true,
+				Name:             "acme",
+				Database:         tt.database,
+				KeyValueStore:    tt.keyValueStore,
+				Git:              false,
+				JobProcessor:     tt.jobProcessor,
+				DevJobsDashboard: true,
+				Templ:            false,
+			})
+
+			required := append(devJobsFiles(projectDir),
+				filepath.Join(projectDir, "internal", "html", "ui", "dev_page.templ"),
+			)
+			for _, f := range required {
+				if _, err := os.Stat(f); os.IsNotExist(err) {
+					t.Fatalf("dev jobs dashboard file not created at %s", f)
+				}
+			}
+
+			main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+			if !strings.Contains(main, "jobs.NewDevJobs("+tt.dashboardArg+", logger)") {
+				t.Fatalf("app main should mount the dev jobs dashboard on %s", tt.dashboardArg)
+			}
+		})
+	}
+}
+
+func TestGenerateDevJobsDashboardRequiresJobs(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:            true,
+		Name:             "acme",
+		Database:         initialize.DatabasePostgres,
+		Git:              false,
+		JobProcessor:     initialize.JobProcessorNone,
+		DevJobsDashboard: true,
+	})
+
+	for _, f := range devJobsFiles(projectDir) {
+		if _, err := os.Stat(f); !os.IsNotExist(err) {
+			t.Fatalf("dev jobs dashboard file should not exist without jobs at %s", f)
+		}
+	}
+
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	if strings.Contains(main, "NewDevJobs") {
+		t.Fatal("app main should not mount the dev jobs dashboard without jobs")
+	}
+}
+
+func TestGenerateQueuedMailer(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabaseSQLite3,
+		Git:          false,
+		SMTP:         true,
+		JobProcessor: initialize.JobProcessorDatabase,
+	})
+
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "jobs", "mail.go")); os.IsNotExist(err) {
+		t.Fatal("jobs mail file not created")
+	}
+
+	// The app queues emails and the worker delivers them through the mailer.
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	for _, want := range []string{"queuedMailer := jobs.RegisterMailer(jobsClient, mailer)", "\t\tqueuedMailer,\n"} {
+		if !strings.Contains(main, want) {
+			t.Fatalf("app main should contain %q", want)
+		}
+	}
+	worker := mustReadFile(t, filepath.Join(projectDir, "cmd", "worker", "main.go"))
+	if !strings.Contains(worker, "jobs.RegisterMailer(jobsClient, mailer)") {
+		t.Fatal("worker main should register the send email task")
+	}
+
+	// The task captures the mailer it delivers through.
+	mail := mustReadFile(t, filepath.Join(projectDir, "internal", "jobs", "mail.go"))
+	if strings.Contains(mail, "var deliveryMailer") || !strings.Contains(mail, "mailer.Send(ctx, email)") {
+		t.Fatal("send email task should capture the registered mailer")
+	}
+
+	if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateMailerWithoutJobs(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabaseSQLite3,
+		Git:          false,
+		SMTP:         true,
+		JobProcessor: initialize.JobProcessorNone,
+	})
+
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "jobs", "mail.go")); !os.IsNotExist(err) {
+		t.Fatal("jobs mail file should not exist without jobs")
+	}
+
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	if strings.Contains(main, "queuedMailer") || !strings.Contains(main, "\t\tmailer,\n") {
+		t.Fatal("app main should send emails inline without jobs")
+	}
+}
+
+func TestGenerateMailerWithStorage(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabaseSQLite3,
+		Git:          false,
+		SMTP:         true,
+		Storage:      true,
+		JobProcessor: initialize.JobProcessorDatabase,
+	})
+
+	// Attachments may reference storage objects, so both mailers get the
+	// storage.
+	bootstrap := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "bootstrap", "bootstrap.go"))
+	for _, want := range []string{
+		"Storage:  stor,",
+		`smtp.NewDevMailbox(vars.SMTPFrom, "tmp/snowflake_dev_mailbox.json", stor, logger)`,
+	} {
+		if !strings.Contains(bootstrap, want) {
+			t.Fatalf("bootstrap should contain %q", want)
+		}
+	}
+
+	// Attachments are streamed from storage, or spooled to a temporary file
+	// for SMTP, which writes them again on every attempt, rather than read
+	// into memory.
+	mailer := mustReadFile(t, filepath.Join(projectDir, "internal", "smtp", "mailer.go"))
+	if !strings.Contains(mailer, "stor.Open(ctx, a.StorageKey)") || strings.Contains(mailer, "io.ReadAll") {
+		t.Fatal("attachments should be streamed with Storage.Open")
+	}
+	smtpMailer := mustReadFile(t, filepath.Join(projectDir, "internal", "smtp", "smtp.go"))
+	if !strings.Contains(smtpMailer, "message.AttachFile(path,") {
+		t.Fatal("SMTP attachments from storage should be spooled to a file")
+	}
+
+	// The worker builds storage too, for email attachments.
+	for _, path := range []string{
+		filepath.Join(projectDir, "cmd", "app", "main.go"),
+		filepath.Join(projectDir, "cmd", "worker", "main.go"),
+	} {
+		main := mustReadFile(t, path)
+		storage := strings.Index(main, "bootstrap.NewStorage(ctx, vars, logger)")
+		if storage < 0 || storage > strings.Index(main, "bootstrap.NewMailer(vars, stor,") {
+			t.Fatalf("%s should create the storage before the mailer", path)
+		}
+	}
+
+	if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateSMTPCaptureServer(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		SMTP:     true,
+	})
+
+	for _, name := range []string{"capture.go", "capture_test.go", "dev_mailbox_test.go"} {
+		if _, err := os.Stat(filepath.Join(projectDir, "internal", "smtp", name)); err != nil {
+			t.Fatalf("%s should be generated with SMTP: %v", name, err)
+		}
+	}
+
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	for _, want := range []string{
+		"smtp.NewCaptureServer(mailbox, logger)",
+		"capture.ListenAndServe(ctx, vars.DevSMTPAddr)",
+	} {
+		if !strings.Contains(main, want) {
+			t.Fatalf("app main should contain %q", want)
+		}
+	}
+	bootstrap := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "bootstrap", "bootstrap.go"))
+	for _, want := range []string{
+		`case vars.Environment == "production", vars.SMTPHost != "", vars.MailTransport == "http":`,
+		"TLS:      vars.SMTPTLS,",
+	} {
+		if !strings.Contains(bootstrap, want) {
+			t.Fatalf("bootstrap should contain %q", want)
+		}
+	}
+
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	for _, want := range []string{"SMTP_TLS=mandatory", "DEV_SMTP_ADDR="} {
+		if !strings.Contains(env, want) {
+			t.Fatalf(".env should contain %q", want)
+		}
+	}
+
+	if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateMailTransports(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:        true,
+		Name:         "acme",
+		Database:     initialize.DatabaseSQLite3,
+		Git:          false,
+		SMTP:         true,
+		JobProcessor: initialize.JobProcessorDatabase,
+	})
+
+	for _, name := range []string{"http.go", "http_test.go", "retry.go", "transport.go"} {
+		if _, err := os.Stat(filepath.Join(projectDir, "internal", "smtp", name)); err != nil {
+			t.Fatalf("%s should be generated with SMTP: %v", name, err)
+		}
+	}
+
+	// The app and the worker pick the same transport.
+	bootstrap := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "bootstrap", "bootstrap.go"))
+	for _, want := range []string{
+		"smtp.NewTransport(vars.MailTransport, &smtp.SMTPMailerConfig{",
+		"Endpoint:   vars.MailHTTPEndpoint,",
+	} {
+		if !strings.Contains(bootstrap, want) {
+			t.Fatalf("bootstrap should contain %q", want)
+		}
+	}
+	for _, path := range []string{
+		filepath.Join(projectDir, "cmd", "app", "main.go"),
+		filepath.Join(projectDir, "cmd", "worker", "main.go"),
+	} {
+		if !strings.Contains(mustReadFile(t, path), "bootstrap.NewMailer(vars,") {
+			t.Fatalf("%s should build the mailer with bootstrap", path)
+		}
+	}
+
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	for _, want := range []string{"MAIL_TRANSPORT=smtp", "MAIL_HTTP_ENDPOINT=", "MAIL_HTTP_AUTH_HEADER=Authorization"} {
+		if !strings.Contains(env, want) {
+			t.Fatalf(".env should contain %q", want)
+		}
+	}
+}
+
+func TestGenerateMailGuard(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       initialize.Config
+		store     string
+		migration bool
+		storeArg  string
+	}{
+		{
+			name:      "database",
+			cfg:       initialize.Config{Database: initialize.DatabaseMySQL, KeyValueStore: initialize.KeyValueStoreRedis, JobProcessor: initialize.JobProcessorKeyValueStore},
+			store:     "guard_sql.go",
+			migration: true,
+			storeArg:  "smtp.NewGuardStore(database)",
+		},
+		{
+			name:     "kvs",
+			cfg:      initialize.Config{Database: initialize.DatabaseNone, KeyValueStore: initialize.KeyValueStoreValkey, JobProcessor: initialize.JobProcessorKeyValueStore},
+			store:    "guard_kv.go",
+			storeArg: "smtp.NewGuardStore(vk)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Quiet = true
+			cfg.Name = "acme"
+			cfg.SMTP = true
+			projectDir := generateProject(t, cfg)
+
+			for _, name := range []string{"guard.go", "guard_test.go", tt.store} {
+				if _, err := os.Stat(filepath.Join(projectDir, "internal", "smtp", name)); err != nil {
+					t.Fatalf("%s should be generated: %v", name, err)
+				}
+			}
+
+			migration := filepath.Join(projectDir, "cmd", "app", "sql", "migrations", "00001_email_guard.sql")
+			if tt.migration {
+				if !strings.Contains(mustReadFile(t, migration), "CREATE TABLE email_suppressions") {
+					t.Fatal("migration should create the suppression list")
+				}
+			} else if _, err := os.Stat(migration); !os.IsNotExist(err) {
+				t.Fatal("migration should not be generated without a database")
+			} else if _, err := os.Stat(filepath.Join(projectDir, "internal", "smtp", "guard_kv_test.go")); err != nil {
+				t.Fatalf("guard_kv_test.go should be generated with the key-value store: %v", err)
+			}
+
+			// The worker delivers queued emails, so it guards them too.
+			for _, path := range []string{
+				filepath.Join(projectDir, "cmd", "app", "main.go"),
+				filepath.Join(projectDir, "cmd", "worker", "main.go"),
+			} {
+				main := mustReadFile(t, path)
+				if !strings.Contains(main, "bootstrap.NewMailer(vars, "+tt.storeArg+", logger)") {
+					t.Fatalf("%s should guard the mailer with %s", path, tt.storeArg)
+				}
+			}
+			bootstrap := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "bootstrap", "bootstrap.go"))
+			if !strings.Contains(bootstrap, "smtp.NewGuardedMailer(mailer, guard,") || !strings.Contains(bootstrap, "Mailbox:        mailbox,") {
+				t.Fatal("bootstrap should guard the mailer and record dropped emails in the dev mailbox")
+			}
+
+			if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGenerateMailGuardRequiresStore(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		SMTP:     true,
+	})
 
-	// The app must wire the jobs client and expose the example route.
-	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
-	if !strings.Contains(router, "HandleEnqueueExampleJob") {
-		t.Fatal("router should register the example job route when jobs are enabled")
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "smtp", "guard.go")); !os.IsNotExist(err) {
+		t.Fatal("guard should not be generated without a database or key-value store")
+	}
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	if strings.Contains(main, "NewGuardedMailer") {
+		t.Fatal("app main should not guard the mailer without a store")
 	}
+}
 
-	// The migrator must install the absurd schema.
-	migrator := mustReadFile(t, filepath.Join(projectDir, "cmd", "migrator", "main.go"))
-	if !strings.Contains(migrator, "jobs.Setup") {
-		t.Fatal("migrator should set up jobs when jobs are enabled")
+func TestGenerateTenancy(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabasePostgres,
+		Git:      false,
+		Tenancy:  true,
+	})
+
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "tenant", "tenant.go")); os.IsNotExist(err) {
+		t.Fatal("tenant package not created")
 	}
 
-	// The app must register tasks and run the worker in-process.
-	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
-	if !strings.Contains(main, "jobs.Register") {
-		t.Fatal("app main should register jobs when jobs are enabled")
+	// gen resource adds a tenant-scoped group for each resource it registers.
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	if strings.Contains(router, "tenanted") {
+		t.Fatal("router should not declare an unused tenanted group")
 	}
 	server := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "server.go"))
-	if !strings.Contains(server, "RunWorker") {
-		t.Fatal("app server should run the worker when jobs are enabled")
+	if !strings.Contains(server, "tenant  tenant.Config") {
+		t.Fatal("server should keep the tenant config for generated routes")
+	}
+
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	if !strings.Contains(env, "TENANT_SOURCE=header") {
+		t.Fatal(".env should configure the tenant source")
 	}
 
 	if err := assertInternalImportsResolve(projectDir, "acme"); err != nil {
@@ -454,42 +1159,22 @@ func TestGenerateJobs(t *testing.T) {
 	}
 }
 
-func TestGenerateNoJobs(t *testing.T) {
+func TestGenerateTenancyRequiresDatabase(t *testing.T) {
 	projectDir := generateProject(t, initialize.Config{
-		Quiet:        true,
-		Name:         "acme",
-		Database:     initialize.DatabasePostgres,
-		Git:          false,
-		JobProcessor: initialize.JobProcessorNone,
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Tenancy:  true,
 	})
 
-	for _, f := range jobsFiles(projectDir) {
-		if _, err := os.Stat(f); !os.IsNotExist(err) {
-			t.Fatalf("jobs file should not exist at %s", f)
-		}
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "tenant")); !os.IsNotExist(err) {
+		t.Fatal("tenant package should not exist without a database")
 	}
 
 	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
-	if strings.Contains(router, "HandleEnqueueExampleJob") {
-		t.Fatal("router should not reference jobs when jobs are disabled")
-	}
-}
-
-func TestGenerateJobsRequiresPostgres(t *testing.T) {
-	// Job processing is Postgres-only; it must be forced off for other databases
-	// even when explicitly requested.
-	projectDir := generateProject(t, initialize.Config{
-		Quiet:        true,
-		Name:         "acme",
-		Database:     initialize.DatabaseSQLite3,
-		Git:          false,
-		JobProcessor: initialize.JobProcessorAbsurd,
-	})
-
-	for _, f := range jobsFiles(projectDir) {
-		if _, err := os.Stat(f); !os.IsNotExist(err) {
-			t.Fatalf("jobs file should not exist for non-postgres database at %s", f)
-		}
+	if strings.Contains(router, "tenant") {
+		t.Fatal("router should not reference tenancy without a database")
 	}
 }
 
@@ -579,6 +1264,381 @@ func TestGeneratedDevStorageCreatesStorageDirectory(t *testing.T) {
 	}
 }
 
+func TestGenerateStorageStreaming(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Storage:  true,
+	})
+
+	storage := mustReadFile(t, filepath.Join(projectDir, "internal", "storage", "storage.go"))
+	for _, want := range []string{
+		"Open(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)",
+		"Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (ObjectInfo, error)",
+	} {
+		if !strings.Contains(storage, want) {
+			t.Fatalf("storage interface should declare %q", want)
+		}
+	}
+
+	// Large uploads go through the transfer manager rather than one buffered
+	// PutObject.
+	s3 := mustReadFile(t, filepath.Join(projectDir, "internal", "storage", "s3.go"))
+	if !strings.Contains(s3, "s.transfer.UploadObject(ctx, input)") {
+		t.Fatal("S3 storage should upload through the transfer manager")
+	}
+	if strings.Contains(s3, "io.ReadAll") {
+		t.Fatal("S3 storage should not buffer whole objects")
+	}
+
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	if !strings.Contains(router, `api.GET("/storage/object", handlers.HandleDownloadObject(s.storage))`) {
+		t.Fatal("router should expose object downloads")
+	}
+
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", "dev_storage_test.go")); err != nil {
+		t.Fatalf("dev storage tests should be generated: %v", err)
+	}
+}
+
+func TestGenerateStoragePresign(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Storage:  true,
+	})
+
+	for _, name := range []string{"presign.go", "presign_test.go"} {
+		if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", name)); err != nil {
+			t.Fatalf("%s should be generated with storage: %v", name, err)
+		}
+	}
+
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	for _, want := range []string{
+		`api.GET("/storage/presign/download", handlers.HandlePresignDownload(s.storage, s.presign))`,
+		`api.POST("/storage/presign/upload", handlers.HandlePresignUpload(s.storage, s.presign))`,
+	} {
+		if !strings.Contains(router, want) {
+			t.Fatalf("router should contain %q", want)
+		}
+	}
+
+	// Dev and local storage serve their own presigned URLs, with or without
+	// the dashboard.
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	for _, want := range []string{
+		"pr.PresignedRoutes(srv.router)",
+		"ContentTypes: vars.StoragePresignContentTypes,",
+	} {
+		if !strings.Contains(main, want) {
+			t.Fatalf("app main should contain %q", want)
+		}
+	}
+
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	for _, want := range []string{"STORAGE_PRESIGN_EXPIRY=900", "STORAGE_PRESIGN_CONTENT_TYPES="} {
+		if !strings.Contains(env, want) {
+			t.Fatalf(".env should contain %q", want)
+		}
+	}
+}
+
+func TestGenerateStorageListPagination(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Storage:  true,
+	})
+
+	handler := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "handlers", "storage_handler.go"))
+	for _, want := range []string{
+		"store.ListPage(c.Request.Context(), opts)",
+		`c.Query("continuation_token")`,
+		`"next_continuation_token": page.NextContinuationToken,`,
+	} {
+		if !strings.Contains(handler, want) {
+			t.Fatalf("storage handler should contain %q", want)
+		}
+	}
+
+	s3 := mustReadFile(t, filepath.Join(projectDir, "internal", "storage", "s3.go"))
+	if !strings.Contains(s3, "s3.NewListObjectsV2Paginator(") {
+		t.Fatal("S3 List should page through every object")
+	}
+}
+
+func TestGenerateStorageDashboardRoutes(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:               true,
+		Name:                "acme",
+		Database:            initialize.DatabaseNone,
+		Git:                 false,
+		Storage:             true,
+		DevStorageDashboard: true,
+	})
+
+	devStorage := mustReadFile(t, filepath.Join(projectDir, "internal", "storage", "dev_storage.go"))
+	for _, want := range []string{
+		`rg.GET("/download", handleDownload(s))`,
+		`rg.GET("/preview", handlePreview(s))`,
+		`rg.POST("/rename", handleRename(s))`,
+		"func (s *DevStorage) Rename(key string, newKey string) error {",
+	} {
+		if !strings.Contains(devStorage, want) {
+			t.Fatalf("dev_storage.go should contain %q", want)
+		}
+	}
+	if strings.Contains(devStorage, `"/raw"`) {
+		t.Fatal("the dashboard should not serve objects inline from /raw")
+	}
+
+	projectDir = generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Storage:  true,
+	})
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", "handler.go")); !os.IsNotExist(err) {
+		t.Fatal("dashboard handlers should not be generated without the dashboard")
+	}
+}
+
+func TestGenerateStorageImageVariants(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Storage:  true,
+	})
+
+	for _, name := range []string{"image.go", "image_test.go"} {
+		if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", name)); err != nil {
+			t.Fatalf("%s should be generated with storage: %v", name, err)
+		}
+	}
+
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	for _, want := range []string{
+		"router.UseRawPath = true",
+		`api.GET("/storage/:key", handlers.HandleGetObject(s.storage))`,
+	} {
+		if !strings.Contains(router, want) {
+			t.Fatalf("router should contain %q", want)
+		}
+	}
+
+	main := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "main.go"))
+	for _, want := range []string{
+		"storage.ParseImageVariants(vars.StorageImageVariants)",
+		"uploads = storage.NewImageStorage(stor, variants, logger)",
+	} {
+		if !strings.Contains(main, want) {
+			t.Fatalf("app main should contain %q", want)
+		}
+	}
+
+	if env := mustReadFile(t, filepath.Join(projectDir, ".env")); !strings.Contains(env, "STORAGE_IMAGE_VARIANTS=thumb=256x256") {
+		t.Fatal(".env should configure a thumbnail variant")
+	}
+}
+
+func TestGenerateWithoutStorageKeepsDecodedPaths(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+	})
+
+	if router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go")); strings.Contains(router, "UseRawPath") {
+		t.Fatal("router should only match raw paths for storage keys")
+	}
+}
+
+func TestGenerateStorageUploadPolicy(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+		Storage:  true,
+	})
+
+	for _, name := range []string{"upload.go", "upload_test.go"} {
+		if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", name)); err != nil {
+			t.Fatalf("%s should be generated with storage: %v", name, err)
+		}
+	}
+
+	handler := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "handlers", "storage_handler.go"))
+	for _, want := range []string{
+		"func HandleUploadObject(store storage.Storage, policy storage.UploadPolicy) gin.HandlerFunc {",
+		"policy.Store(c.Request.Context(), store, fileHeader.Filename, file,",
+		"http.StatusUnsupportedMediaType",
+	} {
+		if !strings.Contains(handler, want) {
+			t.Fatalf("storage handler should contain %q", want)
+		}
+	}
+	if strings.Contains(handler, "store.Put(c.Request.Context(), fileHeader.Filename") {
+		t.Fatal("uploads should not be stored under the raw client filename")
+	}
+
+	router := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "router.go"))
+	if !strings.Contains(router, `api.POST("/storage/upload", handlers.HandleUploadObject(s.storage, s.upload))`) {
+		t.Fatal("router should pass the upload policy to the upload handler")
+	}
+
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	for _, want := range []string{"STORAGE_UPLOAD_MAX_BYTES=10485760", "STORAGE_UPLOAD_PREFIX=uploads/", "STORAGE_UPLOAD_KEYS=uuid"} {
+		if !strings.Contains(env, want) {
+			t.Fatalf(".env should contain %q", want)
+		}
+	}
+}
+
+func TestGenerateLocalStorage(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:         true,
+		Name:          "acme",
+		Database:      initialize.DatabaseNone,
+		KeyValueStore: initialize.KeyValueStoreRedis,
+		JobProcessor:  initialize.JobProcessorKeyValueStore,
+		Git:           false,
+		Storage:       true,
+		SMTP:          true,
+	})
+
+	for _, name := range []string{"local.go", "local_test.go"} {
+		if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", name)); err != nil {
+			t.Fatalf("%s should be generated with storage: %v", name, err)
+		}
+	}
+
+	bootstrap := mustReadFile(t, filepath.Join(projectDir, "cmd", "app", "bootstrap", "bootstrap.go"))
+	for _, want := range []string{
+		`case vars.StorageDriver == "local":`,
+		"storage.NewLocalStorage(&storage.LocalStorageConfig{",
+		`case vars.StorageDriver == "s3", vars.StorageDriver == "" && vars.Environment == "production":`,
+		`return nil, nil, fmt.Errorf("unknown STORAGE_DRIVER %q", vars.StorageDriver)`,
+	} {
+		if !strings.Contains(bootstrap, want) {
+			t.Fatalf("bootstrap should contain %q", want)
+		}
+	}
+
+	// The worker builds storage too, for email attachments.
+	for _, path := range []string{
+		filepath.Join(projectDir, "cmd", "app", "main.go"),
+		filepath.Join(projectDir, "cmd", "worker", "main.go"),
+	} {
+		if !strings.Contains(mustReadFile(t, path), "bootstrap.NewStorage(ctx, vars, logger)") {
+			t.Fatalf("%s should build the storage with bootstrap", path)
+		}
+	}
+
+	env := mustReadFile(t, filepath.Join(projectDir, ".env"))
+	for _, want := range []string{"STORAGE_DRIVER=", "STORAGE_LOCAL_DIR=tmp/storage", "STORAGE_LOCAL_SECRET="} {
+		if !strings.Contains(env, want) {
+			t.Fatalf(".env should contain %q", want)
+		}
+	}
+}
+
+func TestGenerateWithoutStorageOmitsLocalStorage(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseNone,
+		Git:      false,
+	})
+
+	if _, err := os.Stat(filepath.Join(projectDir, "internal", "storage", "local.go")); !os.IsNotExist(err) {
+		t.Fatalf("local.go should not be generated without storage: %v", err)
+	}
+	if env := mustReadFile(t, filepath.Join(projectDir, ".env")); strings.Contains(env, "STORAGE_DRIVER") {
+		t.Fatal(".env should not configure a storage driver without storage")
+	}
+}
+
+func TestGenerateDevS3(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabaseSQLite3,
+		Git:      false,
+		Storage:  true,
+		DevS3:    true,
+	})
+
+	// MinIO alone is enough for a dev environment.
+	devenv := mustReadFile(t, filepath.Join(projectDir, "devenv.yaml"))
+	for _, want := range []string{
+		"image: minio/minio",
+		"minio-init:",
+		"condition: service_healthy",
+		"mc mb --ignore-existing local/acme",
+		"minio_acme_data:",
+	} {
+		if !strings.Contains(devenv, want) {
+			t.Fatalf("devenv.yaml should contain %q", want)
+		}
+	}
+
+	for _, name := range []string{".env", ".env.example"} {
+		env := mustReadFile(t, filepath.Join(projectDir, name))
+		for _, want := range []string{
+			"STORAGE_DRIVER=s3\n",
+			"S3_ACCESS_KEY=minioadmin\n",
+			"S3_SECRET_KEY=minioadmin\n",
+			"S3_ENDPOINT_URL=http://localhost:9000\n",
+			"S3_BUCKET=acme\n",
+		} {
+			if !strings.Contains(env, want) {
+				t.Fatalf("%s should contain %q", name, want)
+			}
+		}
+	}
+
+	// Tests keep using the in-memory storage.
+	if env := mustReadFile(t, filepath.Join(projectDir, ".env.test")); strings.Contains(env, "minioadmin") {
+		t.Fatal(".env.test should not point at MinIO")
+	}
+
+	makefile := mustReadFile(t, filepath.Join(projectDir, "Makefile"))
+	if !strings.Contains(makefile, "devenv.up:") {
+		t.Fatal("Makefile should have devenv targets with MinIO")
+	}
+}
+
+func TestGenerateWithoutDevS3OmitsMinIO(t *testing.T) {
+	projectDir := generateProject(t, initialize.Config{
+		Quiet:    true,
+		Name:     "acme",
+		Database: initialize.DatabasePostgres,
+		Git:      false,
+		Storage:  false,
+		DevS3:    true,
+	})
+
+	devenv := mustReadFile(t, filepath.Join(projectDir, "devenv.yaml"))
+	if strings.Contains(devenv, "minio") {
+		t.Fatal("devenv.yaml should not include MinIO without storage")
+	}
+	if !strings.Contains(devenv, "postgres_acme_data:") {
+		t.Fatal("devenv.yaml should keep the database volume")
+	}
+}
+
 func TestGenerateDatabaseScaffoldInternalImportsResolve(t *testing.T) {
 	cfg := initialize.Config{
 		Quiet:     true,
@@ -23,6 +23,11 @@ func NewProject(cfg *Config) *Project {
 		{
 			FilePaths: []string{
 				"/devenv.yaml",
+			},
+			Check: func(p *Project) bool { return !p.HasDevEnv() },
+		},
+		{
+			FilePaths: []string{
 				"/Dockerfile",
 			},
 			Check: func(p *Project) bool {
@@ -33,11 +38,41 @@ func NewProject(cfg *Config) *Project {
 			FilePaths: []string{
 				"/internal/smtp/mailer.go",
 				"/internal/smtp/smtp.go",
+				"/internal/smtp/http.go",
+				"/internal/smtp/http_test.go",
+				"/internal/smtp/retry.go",
+				"/internal/smtp/transport.go",
+				"/internal/smtp/render.go",
+				"/internal/smtp/render_test.go",
 				"/internal/smtp/dev_mailbox.go",
+				"/internal/smtp/dev_mailbox_test.go",
+				"/internal/smtp/capture.go",
+				"/internal/smtp/capture_test.go",
 				"/cmd/app/handlers/send_handler.go",
 			},
 			Check: func(p *Project) bool { return !p.SMTP },
 		},
+		{
+			FilePaths: []string{
+				"/internal/smtp/guard.go",
+				"/internal/smtp/guard_test.go",
+			},
+			Check: func(p *Project) bool { return !p.HasMailGuard() },
+		},
+		{
+			FilePaths: []string{
+				"/internal/smtp/guard_sql.go",
+				"/cmd/app/sql/migrations/00001_email_guard.sql",
+			},
+			Check: func(p *Project) bool { return !p.MailGuardUsesDatabase() },
+		},
+		{
+			FilePaths: []string{
+				"/internal/smtp/guard_kv.go",
+				"/internal/smtp/guard_kv_test.go",
+			},
+			Check: func(p *Project) bool { return !p.HasMailGuard() || p.MailGuardUsesDatabase() },
+		},
 		{
 			FilePaths: []string{
 				"/internal/smtp/handler.go",
@@ -53,6 +88,15 @@ func NewProject(cfg *Config) *Project {
 				"/internal/storage/storage.go",
 				"/internal/storage/s3.go",
 				"/internal/storage/dev_storage.go",
+				"/internal/storage/dev_storage_test.go",
+				"/internal/storage/presign.go",
+				"/internal/storage/presign_test.go",
+				"/internal/storage/local.go",
+				"/internal/storage/local_test.go",
+				"/internal/storage/image.go",
+				"/internal/storage/image_test.go",
+				"/internal/storage/upload.go",
+				"/internal/storage/upload_test.go",
 				"/cmd/app/handlers/storage_handler.go",
 			},
 			Check: func(p *Project) bool { return !p.Storage },
@@ -79,13 +123,23 @@ func NewProject(cfg *Config) *Project {
 			},
 			Check: func(p *Project) bool { return !p.DevDBDashboard },
 		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/dev_jobs.go",
+				"/internal/jobs/dev_jobs_handler.go",
+				"/internal/jobs/dev_jobs_queries.go",
+				"/internal/jobs/dev_jobs_layout.templ",
+				"/internal/jobs/dev_jobs_list.templ",
+				"/internal/jobs/dev_jobs_show.templ",
+			},
+			Check: func(p *Project) bool { return !p.DevJobsDashboard },
+		},
 		{
 			FilePaths: []string{
 				"/sqlc.yaml",
 				"/cmd/app/sql/sql.go",
 				"/cmd/migrator/main.go",
 				"/internal/db/db.go",
-				"/devenv.yaml",
 			},
 			Check: func(p *Project) bool { return p.Database == DatabaseNone },
 		},
@@ -93,11 +147,83 @@ func NewProject(cfg *Config) *Project {
 			FilePaths: []string{
 				"/internal/jobs/jobs.go",
 				"/internal/jobs/tasks.go",
-				"/internal/jobs/absurd.sql",
+				"/internal/jobs/schedule.go",
+				"/internal/jobs/worker.go",
 				"/cmd/app/handlers/jobs_handler.go",
+				"/cmd/worker/main.go",
 			},
 			Check: func(p *Project) bool { return !p.HasJobs() },
 		},
+		{
+			// The scheduler tests run against a SQLite file; other databases
+			// need a server.
+			FilePaths: []string{
+				"/internal/jobs/schedule_test.go",
+			},
+			Check: func(p *Project) bool {
+				return p.JobProcessor != JobProcessorDatabase || p.Database != DatabaseSQLite3
+			},
+		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/mail.go",
+			},
+			Check: func(p *Project) bool { return !p.HasJobs() || !p.SMTP },
+		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/absurd.sql",
+			},
+			Check: func(p *Project) bool { return p.JobProcessor != JobProcessorAbsurd },
+		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/queue/queue.go",
+				"/internal/jobs/queue/inspect.go",
+			},
+			Check: func(p *Project) bool {
+				return p.JobProcessor != JobProcessorDatabase && p.JobProcessor != JobProcessorKeyValueStore
+			},
+		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/queue/store_sql.go",
+			},
+			Check: func(p *Project) bool { return p.JobProcessor != JobProcessorDatabase },
+		},
+		{
+			// The queue tests run against SQLite or an in-process key-value
+			// store, which need no server.
+			FilePaths: []string{
+				"/internal/jobs/queue/queue_test.go",
+			},
+			Check: func(p *Project) bool {
+				return (p.JobProcessor != JobProcessorDatabase || p.Database != DatabaseSQLite3) &&
+					p.JobProcessor != JobProcessorKeyValueStore
+			},
+		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/queue/store_sql_test.go",
+			},
+			Check: func(p *Project) bool {
+				return p.JobProcessor != JobProcessorDatabase || p.Database != DatabaseSQLite3
+			},
+		},
+		{
+			FilePaths: []string{
+				"/internal/jobs/queue/store_kv.go",
+				"/internal/jobs/queue/store_kv_test.go",
+			},
+			Check: func(p *Project) bool { return p.JobProcessor != JobProcessorKeyValueStore },
+		},
+		{
+			FilePaths: []string{
+				"/internal/tenant/tenant.go",
+				"/internal/tenant/tenant_test.go",
+			},
+			Check: func(p *Project) bool { return !p.Tenancy },
+		},
 		{
 			FilePaths: []string{
 				"/internal/html/html.go",
@@ -124,8 +250,41 @@ func (p *Project) HasJobs() bool {
 	return p.JobProcessor != JobProcessorNone
 }
 
+// JobsPackage is the package providing Task, Step and MustTaskContext to the
+// tasks in internal/jobs.
+func (p *Project) JobsPackage() string {
+	if p.JobProcessor == JobProcessorAbsurd {
+		return "absurd"
+	}
+	return "queue"
+}
+
+// JobsUseDatabase reports whether jobs are stored in the database, which the
+// migrator then sets up.
+func (p *Project) JobsUseDatabase() bool {
+	return p.JobProcessor == JobProcessorAbsurd || p.JobProcessor == JobProcessorDatabase
+}
+
+// HasMailGuard reports whether outgoing email goes through a GuardedMailer,
+// which needs a database or a key-value store to keep its state in.
+func (p *Project) HasMailGuard() bool {
+	return p.SMTP && (p.Database != DatabaseNone || p.HasKeyValueStore())
+}
+
+// MailGuardUsesDatabase reports whether the mail guard keeps its state in
+// the database rather than in the key-value store.
+func (p *Project) MailGuardUsesDatabase() bool {
+	return p.HasMailGuard() && p.Database != DatabaseNone
+}
+
+// HasDevEnv reports whether devenv.yaml has any containers to run: a
+// database server, a key-value store or MinIO.
 func (p *Project) HasDevEnv() bool {
-	return !(p.Database == DatabaseSQLite3 && !p.HasKeyValueStore())
+	switch p.Database {
+	case DatabasePostgres, DatabaseMySQL, DatabaseMariaDB:
+		return true
+	}
+	return p.HasKeyValueStore() || p.DevS3
 }
 
 func (p *Project) ExcludeFile(templateFileName string) bool {
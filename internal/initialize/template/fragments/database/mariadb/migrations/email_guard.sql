@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE email_suppressions (
+  address VARCHAR(320) NOT NULL PRIMARY KEY,
+  reason VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE email_send_counts (
+  address VARCHAR(320) NOT NULL,
+  window_start BIGINT NOT NULL,
+  sends INT NOT NULL,
+  PRIMARY KEY (address, window_start)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE email_send_counts;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE email_suppressions;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE email_suppressions (
+  address TEXT PRIMARY KEY,
+  reason TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE email_send_counts (
+  address TEXT NOT NULL,
+  window_start BIGINT NOT NULL,
+  sends INTEGER NOT NULL,
+  PRIMARY KEY (address, window_start)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE email_send_counts;
+DROP TABLE email_suppressions;
+-- +goose StatementEnd
@@ -147,11 +147,18 @@ type JobProcessor string
 const (
 	JobProcessorNone   JobProcessor = "none"
 	JobProcessorAbsurd JobProcessor = "absurd"
+	// JobProcessorDatabase keeps jobs in a table of the application database,
+	// for the engines Absurd does not support.
+	JobProcessorDatabase JobProcessor = "database"
+	// JobProcessorKeyValueStore keeps jobs in the configured Redis or Valkey.
+	JobProcessorKeyValueStore JobProcessor = "kvs"
 )
 
 var AllJobProcessors = []JobProcessor{
 	JobProcessorNone,
 	JobProcessorAbsurd,
+	JobProcessorDatabase,
+	JobProcessorKeyValueStore,
 }
 
 func (j JobProcessor) IsValid() bool {